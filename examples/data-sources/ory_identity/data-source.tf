@@ -3,6 +3,11 @@ data "ory_identity" "user" {
   id = "identity-uuid"
 }
 
+# Look up an identity by its external ID
+data "ory_identity" "legacy_user" {
+  external_id = "auth0|108251234567890123456"
+}
+
 output "identity_state" {
   value = data.ory_identity.user.state
 }
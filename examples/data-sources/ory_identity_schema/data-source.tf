@@ -0,0 +1,18 @@
+# Look up the project's default identity schema
+data "ory_identity_schema" "default" {
+  default = true
+}
+
+# Look up a schema by ID
+data "ory_identity_schema" "customer" {
+  id = ory_identity_schema.customer.id
+}
+
+# Traits a login form has to ask for
+output "login_identifiers" {
+  value = [for t in data.ory_identity_schema.default.traits : t.name if t.identifier]
+}
+
+output "required_traits" {
+  value = [for t in data.ory_identity_schema.customer.traits : t.name if t.required]
+}
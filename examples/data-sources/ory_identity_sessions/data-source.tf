@@ -0,0 +1,18 @@
+# List the active sessions of an identity
+data "ory_identity_sessions" "user" {
+  identity_id = ory_identity.user.id
+}
+
+output "session_devices" {
+  value = flatten([
+    for s in data.ory_identity_sessions.user.sessions : [
+      for d in s.devices : "${s.aal} ${d.ip_address} ${d.user_agent} (expires ${s.expires_at})"
+    ]
+  ])
+}
+
+# Include sessions that were revoked or have expired
+data "ory_identity_sessions" "history" {
+  identity_id = ory_identity.user.id
+  active_only = false
+}
@@ -0,0 +1,35 @@
+# Grant a user view access to a document
+resource "ory_relationship" "alice_views_doc" {
+  namespace  = "documents"
+  object     = "doc-123"
+  relation   = "viewer"
+  subject_id = "user-alice"
+}
+
+# Verify the tuple grants the expected access
+data "ory_permission_check" "alice_can_view" {
+  namespace  = ory_relationship.alice_views_doc.namespace
+  object     = ory_relationship.alice_views_doc.object
+  relation   = "viewer"
+  subject_id = "user-alice"
+}
+
+# Check access through a subject set: are members of the
+# engineering group viewers of the document?
+data "ory_permission_check" "engineering_can_view" {
+  namespace             = "documents"
+  object                = "doc-123"
+  relation              = "viewer"
+  subject_set_namespace = "groups"
+  subject_set_object    = "engineering"
+  subject_set_relation  = "member"
+  max_depth             = 5
+}
+
+# Fail the run if the permission model does not behave as expected
+check "alice_can_view_doc" {
+  assert {
+    condition     = data.ory_permission_check.alice_can_view.allowed
+    error_message = "user-alice should be able to view doc-123."
+  }
+}
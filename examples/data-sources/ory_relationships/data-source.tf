@@ -0,0 +1,24 @@
+# List every member of the engineering group, including subject sets
+data "ory_relationships" "engineering_members" {
+  namespace = "groups"
+  object    = "engineering"
+  relation  = "members"
+}
+
+output "engineering_user_ids" {
+  value = [
+    for r in data.ory_relationships.engineering_members.relationships : r.subject_id
+    if r.subject_id != null
+  ]
+}
+
+# Find every document a user can view
+data "ory_relationships" "alice_documents" {
+  namespace  = "documents"
+  relation   = "viewer"
+  subject_id = "user-alice"
+}
+
+output "alice_document_ids" {
+  value = data.ory_relationships.alice_documents.relationships[*].object
+}
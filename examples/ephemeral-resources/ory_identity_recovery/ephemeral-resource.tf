@@ -0,0 +1,21 @@
+# Break-glass recovery link for a locked-out admin
+ephemeral "ory_identity_recovery" "admin" {
+  identity_id = ory_identity.admin.id
+  expires_in  = "30m"
+  return_to   = "https://console.example.com/settings"
+}
+
+# One-time recovery code, entered on the page at recovery_link
+ephemeral "ory_identity_recovery" "admin_code" {
+  identity_id = ory_identity.admin.id
+  method      = "code"
+  expires_in  = "15m"
+}
+
+# Hand the link to the on-call engineer without persisting it, e.g. by
+# writing it to a secrets manager that accepts write-only arguments
+resource "aws_secretsmanager_secret_version" "break_glass" {
+  secret_id                = aws_secretsmanager_secret.break_glass.id
+  secret_string_wo         = ephemeral.ory_identity_recovery.admin.recovery_link
+  secret_string_wo_version = 1
+}
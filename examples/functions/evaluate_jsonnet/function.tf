@@ -0,0 +1,27 @@
+locals {
+  registration_webhook_body = <<-JSONNET
+    function(ctx) {
+      identity_id: ctx.identity.id,
+      email: ctx.identity.traits.email
+    }
+  JSONNET
+}
+
+resource "ory_action" "user_registered" {
+  flow   = "registration"
+  timing = "after"
+  url    = "https://api.example.com/webhooks/user-registered"
+  body   = local.registration_webhook_body
+}
+
+# Render the webhook body for a sample identity
+output "registration_webhook_body" {
+  value = provider::ory::evaluate_jsonnet(local.registration_webhook_body, jsonencode({
+    ctx = {
+      identity = {
+        id     = "9f425a8d-7efc-4768-8f23-7647a74fdf13"
+        traits = { email = "user@example.com" }
+      }
+    }
+  }))
+}
@@ -0,0 +1,12 @@
+# Unit test a social sign-in mapper with `terraform test`
+run "maps_github_claims" {
+  command = plan
+
+  assert {
+    condition = jsondecode(provider::ory::evaluate_jsonnet(
+      file("${path.module}/github-mapper.jsonnet"),
+      jsonencode({ claims = { email = "user@example.com", email_verified = true } })
+    )).identity.traits.email == "user@example.com"
+    error_message = "The mapper must copy the email claim to the email trait."
+  }
+}
@@ -57,3 +57,23 @@ resource "ory_action" "enrich_identity" {
     }
   JSONNET
 }
+
+# Webhook authenticated with an API key header
+resource "ory_action" "crm_sync" {
+  flow        = "registration"
+  timing      = "after"
+  auth_method = "password"
+  url         = "https://api.example.com/webhooks/crm"
+
+  auth = {
+    type  = "api_key"
+    name  = "X-API-Key"
+    value = var.crm_webhook_api_key
+  }
+}
+
+variable "crm_webhook_api_key" {
+  type        = string
+  sensitive   = true
+  description = "API key the CRM webhook receiver expects in the X-API-Key header"
+}
@@ -0,0 +1,20 @@
+# Sign users in right after they register with a password
+resource "ory_flow_hook" "session_after_registration" {
+  flow        = "registration"
+  auth_method = "password"
+  hook        = "session"
+}
+
+# Log out all other sessions after a password login
+resource "ory_flow_hook" "single_session" {
+  flow        = "login"
+  auth_method = "password"
+  hook        = "revoke_active_sessions"
+}
+
+# Only allow logins with a verified email address
+resource "ory_flow_hook" "require_verified_address" {
+  flow        = "login"
+  auth_method = "password"
+  hook        = "require_verified_address"
+}
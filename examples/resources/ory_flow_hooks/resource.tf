@@ -0,0 +1,52 @@
+# Own the complete list of hooks that run after a password registration.
+# Hooks run in the order listed here.
+resource "ory_flow_hooks" "registration_password" {
+  flow        = "registration"
+  timing      = "after"
+  auth_method = "password"
+
+  hooks = [
+    {
+      hook = "web_hook"
+      config = jsonencode({
+        url    = "https://api.example.com/webhooks/user-registered"
+        method = "POST"
+        body   = <<-JSONNET
+          function(ctx) {
+            identity_id: ctx.identity.id,
+            email: ctx.identity.traits.email
+          }
+        JSONNET
+        response = {
+          ignore = true
+        }
+      })
+    },
+    {
+      hook = "session"
+    },
+    {
+      hook = "show_verification_ui"
+    },
+  ]
+}
+
+# Validate registrations before the form is shown
+resource "ory_flow_hooks" "registration_before" {
+  flow   = "registration"
+  timing = "before"
+
+  hooks = [
+    {
+      hook = "web_hook"
+      config = jsonencode({
+        url    = "https://api.example.com/webhooks/registration-allowed"
+        method = "POST"
+        response = {
+          parse = false
+        }
+        can_interrupt = true
+      })
+    },
+  ]
+}
@@ -35,7 +35,53 @@ resource "ory_identity" "customer" {
   })
 }
 
+# Service account that can sign in with a one-time code right away
+resource "ory_identity" "service_account" {
+  schema_id = "preset://email"
+  traits = jsonencode({
+    email = "ci-bot@example.com"
+  })
+
+  verifiable_addresses = [
+    {
+      value    = "ci-bot@example.com"
+      verified = true
+    },
+  ]
+
+  recovery_addresses = [
+    {
+      value = "ci-bot@example.com"
+    },
+  ]
+}
+
+# Identity migrated from another system, keeping the password hash
+# and the linked Google account
+resource "ory_identity" "migrated_user" {
+  schema_id   = "preset://email"
+  external_id = "auth0|108251234567890123456"
+  traits = jsonencode({
+    email = "migrated@example.com"
+  })
+  credentials = {
+    hashed_password = var.migrated_password_hash
+    oidc = [
+      {
+        provider = "google"
+        subject  = "108251234567890123456"
+      },
+    ]
+  }
+}
+
 variable "user_password" {
   type      = string
   sensitive = true
 }
+
+variable "migrated_password_hash" {
+  type        = string
+  sensitive   = true
+  description = "Password hash exported from the previous system, e.g. $2a$10$..."
+}
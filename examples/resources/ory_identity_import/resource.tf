@@ -0,0 +1,20 @@
+# Import users from a JSONL export. Each line is one identity, e.g.:
+# {"external_id":"auth0|123","traits":{"email":"jane@example.com"},"credentials":{"password":{"config":{"hashed_password":"$2a$10$..."}}}}
+resource "ory_identity_import" "legacy_users" {
+  source    = "${path.module}/users.jsonl"
+  schema_id = "preset://email"
+}
+
+# Import from CSV with traits.<name> columns, e.g.:
+# external_id,traits.email,traits.name.first,hashed_password
+# legacy-1,jane@example.com,Jane,$2a$10$...
+resource "ory_identity_import" "crm_contacts" {
+  source              = "${path.module}/contacts.csv"
+  schema_id           = "customer"
+  batch_size          = 1000
+  requests_per_second = 2
+}
+
+output "imported_users" {
+  value = ory_identity_import.legacy_users.imported_count
+}
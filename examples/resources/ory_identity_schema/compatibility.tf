@@ -0,0 +1,9 @@
+# Fail the plan when a change would break existing identities, and check the
+# new content against up to 100 of them.
+resource "ory_identity_schema" "customer" {
+  schema_id                 = "customer"
+  compatibility_check       = "error"
+  compatibility_sample_size = 100
+
+  schema = file("${path.module}/schemas/customer.schema.json")
+}
@@ -0,0 +1,5 @@
+resource "ory_identity_schema" "email" {
+  schema_id   = "email"
+  preset      = "email_password"
+  set_default = true
+}
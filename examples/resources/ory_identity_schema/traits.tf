@@ -0,0 +1,29 @@
+resource "ory_identity_schema" "customer" {
+  schema_id = "customer"
+
+  traits = [
+    {
+      name         = "email"
+      title        = "E-Mail"
+      format       = "email"
+      required     = true
+      credentials  = ["password", "code", "passkey"]
+      verification = true
+      recovery     = true
+    },
+    {
+      name        = "phone"
+      title       = "Phone number"
+      format      = "tel"
+      credentials = ["code"]
+    },
+    {
+      name  = "name"
+      title = "Full name"
+    },
+  ]
+}
+
+output "customer_schema" {
+  value = ory_identity_schema.customer.schema
+}
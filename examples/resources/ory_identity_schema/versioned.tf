@@ -0,0 +1,18 @@
+# Each content change registers customer-v2, customer-v3, ... and moves
+# existing customers to the new version.
+resource "ory_identity_schema" "customer" {
+  schema_id          = "customer"
+  versioned          = true
+  set_default        = true
+  migrate_identities = true
+
+  schema = file("${path.module}/schemas/customer.schema.json")
+}
+
+output "customer_schema_version" {
+  value = ory_identity_schema.customer.version
+}
+
+output "customers_not_migrated" {
+  value = ory_identity_schema.customer.migration_failures
+}
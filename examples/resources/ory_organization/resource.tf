@@ -4,6 +4,28 @@ resource "ory_organization" "acme" {
   domains = ["acme.com", "acme.io"]
 }
 
+# Acme's own identity provider, used for logins with @acme.com and @acme.io
+# addresses. The connection is listed in ory_organization.acme.sso_connections.
+resource "ory_social_provider" "acme_sso" {
+  provider_id     = "acme-sso"
+  provider_type   = "generic"
+  client_id       = var.acme_sso_client_id
+  client_secret   = var.acme_sso_client_secret
+  issuer_url      = "https://login.acme.com"
+  scope           = ["openid", "email", "profile"]
+  label           = "Acme SSO"
+  organization_id = ory_organization.acme.id
+}
+
+variable "acme_sso_client_id" {
+  type = string
+}
+
+variable "acme_sso_client_secret" {
+  type      = string
+  sensitive = true
+}
+
 # Multiple tenant organizations
 resource "ory_organization" "globex" {
   label   = "Globex Corporation"
@@ -0,0 +1,50 @@
+# Load the OPL model from a file
+resource "ory_permission_model" "main" {
+  opl = file("${path.module}/namespaces.ts")
+}
+
+# Or define it inline
+resource "ory_permission_model" "inline" {
+  opl = <<-EOT
+    import { Namespace, Context } from "@ory/keto-namespace-types"
+
+    class User implements Namespace {}
+
+    class Group implements Namespace {
+      related: {
+        members: (User | SubjectSet<Group, "members">)[]
+      }
+    }
+
+    class Document implements Namespace {
+      related: {
+        owners: User[]
+        viewers: (User | SubjectSet<Group, "members">)[]
+        parents: Folder[]
+      }
+
+      permits = {
+        edit: (ctx: Context): boolean =>
+          this.related.owners.includes(ctx.subject),
+        view: (ctx: Context): boolean =>
+          this.permits.edit(ctx) ||
+          this.related.viewers.includes(ctx.subject) ||
+          this.related.parents.traverse((f) => f.permits.view(ctx)),
+      }
+    }
+
+    class Folder implements Namespace {
+      related: {
+        viewers: (User | SubjectSet<Group, "members">)[]
+      }
+
+      permits = {
+        view: (ctx: Context): boolean => this.related.viewers.includes(ctx.subject),
+      }
+    }
+  EOT
+}
+
+output "permission_namespaces" {
+  value = ory_permission_model.inline.namespaces
+}
@@ -41,6 +41,11 @@ resource "ory_relationship" "folder_viewer_inheritance" {
   subject_set_relation  = "viewer"
 }
 
+# The same kind of relationship written as a single tuple string
+resource "ory_relationship" "admins_edit_project" {
+  tuple = "projects:project-abc#editor@(groups:admins#member)"
+}
+
 # Complete RBAC example
 locals {
   users = {
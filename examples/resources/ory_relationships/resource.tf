@@ -0,0 +1,48 @@
+# Prerequisite: configure Keto namespaces first
+resource "ory_project_config" "main" {
+  keto_namespaces = ["documents", "groups"]
+}
+
+# Authoritative group membership: any member not listed here is removed
+resource "ory_relationships" "engineering" {
+  namespace = "groups"
+  object    = "engineering"
+  exclusive = true
+
+  tuples = [
+    { relation = "members", subject_id = "user-alice" },
+    { relation = "members", subject_id = "user-bob" },
+    {
+      relation              = "members"
+      subject_set_namespace = "groups"
+      subject_set_object    = "platform"
+      subject_set_relation  = "members"
+    },
+  ]
+
+  depends_on = [ory_project_config.main]
+}
+
+# Many tuples across a namespace, generated from a map
+locals {
+  document_viewers = {
+    "doc-1" = ["user-alice", "user-bob"]
+    "doc-2" = ["user-carol"]
+  }
+}
+
+resource "ory_relationships" "document_viewers" {
+  namespace = "documents"
+
+  tuples = flatten([
+    for doc, users in local.document_viewers : [
+      for user in users : {
+        object     = doc
+        relation   = "viewer"
+        subject_id = user
+      }
+    ]
+  ])
+
+  depends_on = [ory_project_config.main]
+}
@@ -0,0 +1,36 @@
+resource "ory_organization" "acme" {
+  label   = "Acme Corporation"
+  domains = ["acme.com"]
+}
+
+# SAML connection with metadata fetched from the identity provider
+resource "ory_saml_provider" "acme" {
+  provider_id      = "acme-saml"
+  label            = "Acme SSO"
+  idp_metadata_url = "https://login.acme.com/saml/metadata"
+  organization_id  = ory_organization.acme.id
+
+  mapper_jsonnet = <<-JSONNET
+    local claims = std.extVar('claims');
+    {
+      identity: {
+        traits: {
+          email: claims.email,
+          name: {
+            first: claims.given_name,
+            last: claims.family_name,
+          },
+        },
+      },
+    }
+  JSONNET
+}
+
+# SAML connection with inline metadata, validated at plan time
+resource "ory_saml_provider" "okta" {
+  provider_id      = "okta-saml"
+  label            = "Okta"
+  idp_metadata_xml = file("${path.module}/okta-metadata.xml")
+  entity_id        = "http://www.okta.com/exk1a2b3c4d5e6f7g8h9"
+  mapper_url       = "https://example.com/mappers/saml.jsonnet"
+}
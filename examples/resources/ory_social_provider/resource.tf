@@ -14,6 +14,19 @@ resource "ory_social_provider" "github" {
   client_id     = var.github_client_id
   client_secret = var.github_client_secret
   scope         = ["user:email", "read:user"]
+
+  # Inline Jsonnet mapper, sent as a base64:// mapper URL
+  mapper_jsonnet = <<-JSONNET
+    local claims = std.extVar('claims');
+    {
+      identity: {
+        traits: {
+          email: claims.email,
+          username: claims.preferred_username,
+        },
+      },
+    }
+  JSONNET
 }
 
 # Microsoft Azure AD
@@ -26,13 +39,18 @@ resource "ory_social_provider" "microsoft" {
   scope         = ["openid", "profile", "email"]
 }
 
-# Apple Sign-In
+# Apple Sign-In, with the client secret generated from the private key
 resource "ory_social_provider" "apple" {
-  provider_id   = "apple"
-  provider_type = "apple"
-  client_id     = var.apple_client_id
-  client_secret = var.apple_client_secret
-  scope         = ["email", "name"]
+  provider_id          = "apple"
+  provider_type        = "apple"
+  client_id            = var.apple_client_id
+  apple_team_id        = var.apple_team_id
+  apple_private_key_id = var.apple_private_key_id
+  apple_private_key    = var.apple_private_key
+  scope                = ["email", "name"]
+
+  # Accept ID tokens issued to the native iOS app
+  additional_id_token_audiences = [var.apple_ios_bundle_id]
 }
 
 # Generic OIDC Provider with custom claims mapping
@@ -44,6 +62,17 @@ resource "ory_social_provider" "corporate_sso" {
   issuer_url    = "https://sso.example.com"
   scope         = ["openid", "profile", "email"]
 
+  label          = "Corporate SSO"
+  pkce           = "force"
+  claims_source  = "userinfo"
+  subject_source = "userinfo"
+
+  requested_claims = jsonencode({
+    id_token = {
+      email = { essential = true }
+    }
+  })
+
   # Jsonnet mapper for custom claims mapping (base64-encoded)
   mapper_url = "base64://bG9jYWwgY2xhaW1zID0gc3RkLmV4dFZhcignY2xhaW1zJyk7CnsKICBpZGVudGl0eTogewogICAgdHJhaXRzOiB7CiAgICAgIGVtYWlsOiBjbGFpbXMuZW1haWwsCiAgICB9LAogIH0sCn0="
 }
@@ -95,11 +124,23 @@ variable "apple_client_id" {
   type = string
 }
 
-variable "apple_client_secret" {
+variable "apple_team_id" {
+  type = string
+}
+
+variable "apple_private_key_id" {
+  type = string
+}
+
+variable "apple_private_key" {
   type      = string
   sensitive = true
 }
 
+variable "apple_ios_bundle_id" {
+  type = string
+}
+
 variable "sso_client_id" {
   type = string
 }
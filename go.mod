@@ -3,6 +3,7 @@ module github.com/ory/terraform-provider-ory
 go 1.25.7
 
 require (
+	github.com/google/go-jsonnet v0.21.0
 	github.com/hashicorp/terraform-plugin-framework v1.17.0
 	github.com/hashicorp/terraform-plugin-framework-validators v0.19.0
 	github.com/hashicorp/terraform-plugin-go v0.29.0
@@ -10,6 +11,7 @@ require (
 	github.com/hashicorp/terraform-plugin-testing v1.14.0
 	github.com/ory/client-go v1.22.24
 	github.com/ory/x v0.0.729
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 )
 
 require (
@@ -96,4 +98,5 @@ require (
 	google.golang.org/grpc v1.75.1 // indirect
 	google.golang.org/protobuf v1.36.9 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
+	sigs.k8s.io/yaml v1.4.0 // indirect
 )
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -311,6 +312,11 @@ type OryClient struct {
 	// immediately after PatchProject. This cache ensures Read operations
 	// see the latest state after Create/Update.
 	cachedProjects sync.Map
+
+	// identitySchemas caches the ListIdentitySchemas result for the rest of
+	// the run, as plan-time traits validation needs it for every identity.
+	identitySchemasMu sync.Mutex
+	identitySchemas   []ory.IdentitySchemaContainer
 }
 
 // NewOryClient creates a new Ory API client.
@@ -735,6 +741,18 @@ func (c *OryClient) GetIdentity(ctx context.Context, identityID string) (*ory.Id
 	})
 }
 
+// GetIdentityByExternalID retrieves an identity by its external ID with
+// retry on rate limit.
+func (c *OryClient) GetIdentityByExternalID(ctx context.Context, externalID string) (*ory.Identity, error) {
+	return retryWithBackoff(ctx, "getting identity by external ID", func() (*ory.Identity, error) {
+		identity, httpResp, err := c.projectClient.IdentityAPI.GetIdentityByExternalID(ctx, externalID).Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		return identity, err
+	})
+}
+
 // UpdateIdentity updates an identity with retry on rate limit.
 func (c *OryClient) UpdateIdentity(ctx context.Context, identityID string, body ory.UpdateIdentityBody) (*ory.Identity, error) {
 	return retryWithBackoff(ctx, "updating identity", func() (*ory.Identity, error) {
@@ -746,6 +764,154 @@ func (c *OryClient) UpdateIdentity(ctx context.Context, identityID string, body
 	})
 }
 
+// ListIdentitySessions lists the sessions of an identity, following
+// pagination, with retry on rate limit. When activeOnly is set, only active
+// sessions are returned.
+func (c *OryClient) ListIdentitySessions(ctx context.Context, identityID string, activeOnly bool) ([]ory.Session, error) {
+	type page struct {
+		sessions []ory.Session
+		next     string
+	}
+
+	var all []ory.Session
+	pageToken := ""
+	for {
+		result, err := retryWithBackoff(ctx, "listing identity sessions", func() (page, error) {
+			req := c.projectClient.IdentityAPI.ListIdentitySessions(ctx, identityID).PageSize(500)
+			if activeOnly {
+				req = req.Active(true)
+			}
+			if pageToken != "" {
+				req = req.PageToken(pageToken)
+			}
+			sessions, httpResp, err := req.Execute()
+			if httpResp != nil {
+				_ = httpResp.Body.Close()
+			}
+			if err != nil {
+				return page{}, err
+			}
+			return page{sessions: sessions, next: nextPageToken(httpResp)}, nil
+		})
+		if err != nil {
+			return nil, wrapAPIError(err, "listing identity sessions")
+		}
+		all = append(all, result.sessions...)
+
+		pageToken = result.next
+		if pageToken == "" || len(result.sessions) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// ListIdentitiesPage lists one page of identities with retry on rate limit.
+// It returns the token of the next page, or "" on the last page.
+func (c *OryClient) ListIdentitiesPage(ctx context.Context, pageSize int64, pageToken string) ([]ory.Identity, string, error) {
+	type page struct {
+		identities []ory.Identity
+		next       string
+	}
+	result, err := retryWithBackoff(ctx, "listing identities", func() (page, error) {
+		req := c.projectClient.IdentityAPI.ListIdentities(ctx).PageSize(pageSize)
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		}
+		identities, httpResp, err := req.Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		if err != nil {
+			return page{}, err
+		}
+		next := nextPageToken(httpResp)
+		if len(identities) == 0 {
+			next = ""
+		}
+		return page{identities: identities, next: next}, nil
+	})
+	if err != nil {
+		return nil, "", wrapAPIError(err, "listing identities")
+	}
+	return result.identities, result.next, nil
+}
+
+// DeleteIdentitySessions revokes all sessions of an identity with retry on
+// rate limit.
+func (c *OryClient) DeleteIdentitySessions(ctx context.Context, identityID string) error {
+	_, err := retryWithBackoff(ctx, "revoking identity sessions", func() (struct{}, error) {
+		httpResp, err := c.projectClient.IdentityAPI.DeleteIdentitySessions(ctx, identityID).Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		return struct{}{}, err
+	})
+	return wrapAPIError(err, "revoking identity sessions")
+}
+
+// CreateRecoveryLinkForIdentity creates an admin recovery link for an
+// identity with retry on rate limit. returnTo is optional.
+func (c *OryClient) CreateRecoveryLinkForIdentity(ctx context.Context, body ory.CreateRecoveryLinkForIdentityBody, returnTo string) (*ory.RecoveryLinkForIdentity, error) {
+	return retryWithBackoff(ctx, "creating recovery link", func() (*ory.RecoveryLinkForIdentity, error) {
+		req := c.projectClient.IdentityAPI.CreateRecoveryLinkForIdentity(ctx).CreateRecoveryLinkForIdentityBody(body)
+		if returnTo != "" {
+			req = req.ReturnTo(returnTo)
+		}
+		link, httpResp, err := req.Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		return link, err
+	})
+}
+
+// CreateRecoveryCodeForIdentity creates an admin recovery code for an
+// identity with retry on rate limit.
+func (c *OryClient) CreateRecoveryCodeForIdentity(ctx context.Context, body ory.CreateRecoveryCodeForIdentityBody) (*ory.RecoveryCodeForIdentity, error) {
+	return retryWithBackoff(ctx, "creating recovery code", func() (*ory.RecoveryCodeForIdentity, error) {
+		code, httpResp, err := c.projectClient.IdentityAPI.CreateRecoveryCodeForIdentity(ctx).CreateRecoveryCodeForIdentityBody(body).Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		return code, err
+	})
+}
+
+// nextPageToken extracts the page_token of the rel="next" link from a
+// paginated response's Link header. It returns "" on the last page.
+func nextPageToken(httpResp *http.Response) string {
+	if httpResp == nil {
+		return ""
+	}
+	for _, header := range httpResp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			if len(parts) < 2 || !strings.Contains(strings.Join(parts[1:], ";"), `rel="next"`) {
+				continue
+			}
+			target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			parsed, err := url.Parse(target)
+			if err != nil {
+				return ""
+			}
+			return parsed.Query().Get("page_token")
+		}
+	}
+	return ""
+}
+
+// PatchIdentity applies JSON Patch operations to an identity with retry on
+// rate limit.
+func (c *OryClient) PatchIdentity(ctx context.Context, identityID string, patches []ory.JsonPatch) (*ory.Identity, error) {
+	return retryWithBackoff(ctx, "patching identity", func() (*ory.Identity, error) {
+		identity, httpResp, err := c.projectClient.IdentityAPI.PatchIdentity(ctx, identityID).JsonPatch(patches).Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		return identity, err
+	})
+}
+
 // DeleteIdentity deletes an identity with retry on rate limit.
 func (c *OryClient) DeleteIdentity(ctx context.Context, identityID string) error {
 	_, err := retryWithBackoff(ctx, "deleting identity", func() (struct{}, error) {
@@ -758,6 +924,24 @@ func (c *OryClient) DeleteIdentity(ctx context.Context, identityID string) error
 	return err
 }
 
+// BatchPatchIdentities creates identities in bulk with retry on rate limit.
+// Failures of individual patches are reported in the response, not as an
+// error.
+func (c *OryClient) BatchPatchIdentities(ctx context.Context, patches []ory.IdentityPatch) ([]ory.IdentityPatchResponse, error) {
+	result, err := retryWithBackoff(ctx, "batch creating identities", func() (*ory.BatchPatchIdentitiesResponse, error) {
+		body := ory.PatchIdentitiesBody{Identities: patches}
+		result, httpResp, err := c.projectClient.IdentityAPI.BatchPatchIdentities(ctx).PatchIdentitiesBody(body).Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		return result, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Identities, nil
+}
+
 // =============================================================================
 // OAuth2 Client Operations (Project API)
 // =============================================================================
@@ -921,6 +1105,53 @@ func (c *OryClient) GetRelationships(ctx context.Context, namespace string, obje
 	return rels, err
 }
 
+// ListRelationships returns all relationships matching the query, following
+// page tokens until the last page. Unset query fields do not filter.
+func (c *OryClient) ListRelationships(ctx context.Context, query ory.RelationQuery) ([]ory.Relationship, error) {
+	var result []ory.Relationship
+	pageToken := ""
+	for {
+		req := c.projectClient.RelationshipAPI.GetRelationships(ctx)
+		if query.Namespace != nil {
+			req = req.Namespace(*query.Namespace)
+		}
+		if query.Object != nil {
+			req = req.Object(*query.Object)
+		}
+		if query.Relation != nil {
+			req = req.Relation(*query.Relation)
+		}
+		if query.SubjectId != nil {
+			req = req.SubjectId(*query.SubjectId)
+		}
+		if query.SubjectSet != nil {
+			req = req.SubjectSetNamespace(query.SubjectSet.Namespace).
+				SubjectSetObject(query.SubjectSet.Object).
+				SubjectSetRelation(query.SubjectSet.Relation)
+		}
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		}
+
+		rels, err := retryWithBackoff(ctx, "listing relationships", func() (*ory.Relationships, error) {
+			rels, httpResp, err := req.Execute()
+			if httpResp != nil {
+				_ = httpResp.Body.Close()
+			}
+			return rels, err
+		})
+		if err != nil {
+			return nil, wrapAPIError(err, "listing relationships")
+		}
+
+		result = append(result, rels.RelationTuples...)
+		pageToken = rels.GetNextPageToken()
+		if pageToken == "" {
+			return result, nil
+		}
+	}
+}
+
 // DeleteRelationships deletes relationships matching the query.
 func (c *OryClient) DeleteRelationships(ctx context.Context, namespace string, object *string, relation *string, subjectID *string) error {
 	req := c.projectClient.RelationshipAPI.DeleteRelationships(ctx).Namespace(namespace)
@@ -940,6 +1171,50 @@ func (c *OryClient) DeleteRelationships(ctx context.Context, namespace string, o
 	return err
 }
 
+// PatchRelationships applies a batch of relationship inserts and deletes.
+// A single call is applied transactionally: either all of its changes
+// succeed or none. Callers that split changes across several calls get no
+// atomicity across them.
+func (c *OryClient) PatchRelationships(ctx context.Context, patches []ory.RelationshipPatch) error {
+	_, err := retryWithBackoff(ctx, "patching relationships", func() (struct{}, error) {
+		httpResp, err := c.projectClient.RelationshipAPI.PatchRelationships(ctx).RelationshipPatch(patches).Execute()
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+		return struct{}{}, err
+	})
+	return wrapAPIError(err, "patching relationships")
+}
+
+// CheckPermission checks whether the subject has the relation on the object.
+// Exactly one of subjectID or subjectSet should be set. A nil maxDepth uses
+// the server default.
+func (c *OryClient) CheckPermission(ctx context.Context, namespace, object, relation string, subjectID *string, subjectSet *ory.SubjectSet, maxDepth *int64) (bool, error) {
+	req := c.projectClient.PermissionAPI.CheckPermission(ctx).
+		Namespace(namespace).
+		Object(object).
+		Relation(relation)
+	if subjectID != nil {
+		req = req.SubjectId(*subjectID)
+	}
+	if subjectSet != nil {
+		req = req.SubjectSetNamespace(subjectSet.Namespace).
+			SubjectSetObject(subjectSet.Object).
+			SubjectSetRelation(subjectSet.Relation)
+	}
+	if maxDepth != nil {
+		req = req.MaxDepth(*maxDepth)
+	}
+	result, httpResp, err := req.Execute()
+	if httpResp != nil {
+		_ = httpResp.Body.Close()
+	}
+	if err != nil {
+		return false, wrapAPIError(err, "checking permission")
+	}
+	return result.GetAllowed(), nil
+}
+
 // =============================================================================
 // Event Stream Operations (Console API)
 // =============================================================================
@@ -1151,6 +1426,26 @@ func (c *OryClient) ListIdentitySchemas(ctx context.Context) ([]ory.IdentitySche
 	return schemas, nil
 }
 
+// ListIdentitySchemasCached returns the identity schemas of the project,
+// listing them only on the first call. Failed listings are not cached.
+func (c *OryClient) ListIdentitySchemasCached(ctx context.Context) ([]ory.IdentitySchemaContainer, error) {
+	c.identitySchemasMu.Lock()
+	defer c.identitySchemasMu.Unlock()
+
+	if c.identitySchemas != nil {
+		return c.identitySchemas, nil
+	}
+	schemas, err := c.ListIdentitySchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schemas == nil {
+		schemas = []ory.IdentitySchemaContainer{}
+	}
+	c.identitySchemas = schemas
+	return schemas, nil
+}
+
 // ListWorkspaces lists all workspaces.
 func (c *OryClient) ListWorkspaces(ctx context.Context) ([]ory.Workspace, error) {
 	resp, httpResp, err := c.consoleClient.WorkspaceAPI.ListWorkspaces(ctx).Execute()
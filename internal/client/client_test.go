@@ -1,7 +1,11 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ory/terraform-provider-ory/internal/testutil"
@@ -396,3 +400,71 @@ func TestIsRetryableError(t *testing.T) {
 		})
 	}
 }
+
+func TestNextPageToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		expected string
+	}{
+		{
+			name:     "next and first links",
+			link:     `</admin/identities/abc/sessions?page_size=500&page_token=eyJwYWdlIjoyfQ>; rel="next",</admin/identities/abc/sessions?page_size=500>; rel="first"`,
+			expected: "eyJwYWdlIjoyfQ",
+		},
+		{
+			name:     "last page",
+			link:     `</admin/identities/abc/sessions?page_size=500>; rel="first"`,
+			expected: "",
+		},
+		{
+			name:     "no link header",
+			link:     "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.link != "" {
+				resp.Header.Set("Link", tt.link)
+			}
+			if got := nextPageToken(resp); got != tt.expected {
+				t.Errorf("nextPageToken() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOryClient_ListIdentitySchemasCached(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"preset://email","schema":{"type":"object"}}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewOryClient(OryClientConfig{
+		ProjectAPIKey: testutil.TestProjectAPIKey,
+		ProjectSlug:   testutil.TestProjectSlug,
+		ProjectAPIURL: server.URL + "/%s",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		schemas, err := client.ListIdentitySchemasCached(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(schemas) != 1 || schemas[0].Id != "preset://email" {
+			t.Fatalf("unexpected schemas: %v", schemas)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
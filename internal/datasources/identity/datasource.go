@@ -2,14 +2,18 @@ package identity
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
 )
 
 var (
@@ -26,14 +30,16 @@ type IdentityDataSource struct {
 }
 
 type IdentityDataSourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	SchemaID       types.String `tfsdk:"schema_id"`
-	SchemaURL      types.String `tfsdk:"schema_url"`
-	State          types.String `tfsdk:"state"`
-	Traits         types.String `tfsdk:"traits"`
-	MetadataPublic types.String `tfsdk:"metadata_public"`
-	CreatedAt      types.String `tfsdk:"created_at"`
-	UpdatedAt      types.String `tfsdk:"updated_at"`
+	ID             types.String           `tfsdk:"id"`
+	SchemaID       types.String           `tfsdk:"schema_id"`
+	SchemaURL      types.String           `tfsdk:"schema_url"`
+	State          types.String           `tfsdk:"state"`
+	Traits         helpers.NormalizedJSON `tfsdk:"traits"`
+	MetadataPublic helpers.NormalizedJSON `tfsdk:"metadata_public"`
+	ExternalID     types.String           `tfsdk:"external_id"`
+	OrganizationID types.String           `tfsdk:"organization_id"`
+	CreatedAt      types.String           `tfsdk:"created_at"`
+	UpdatedAt      types.String           `tfsdk:"updated_at"`
 }
 
 func (d *IdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,8 +51,21 @@ func (d *IdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		Description: "Fetches information about an Ory identity.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "The identity ID to look up.",
-				Required:    true,
+				Description: "The identity ID to look up. Exactly one of id or external_id must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("external_id")),
+				},
+			},
+			"external_id": schema.StringAttribute{
+				Description: "The external ID to look up the identity by.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "The ID of the organization the identity belongs to.",
+				Computed:    true,
 			},
 			"schema_id": schema.StringAttribute{
 				Description: "The identity schema ID.",
@@ -62,10 +81,12 @@ func (d *IdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 			},
 			"traits": schema.StringAttribute{
 				Description: "Identity traits as a JSON string.",
+				CustomType:  helpers.NormalizedJSONType{},
 				Computed:    true,
 			},
 			"metadata_public": schema.StringAttribute{
 				Description: "Public metadata as a JSON string.",
+				CustomType:  helpers.NormalizedJSONType{},
 				Computed:    true,
 			},
 			"created_at": schema.StringAttribute{
@@ -101,7 +122,13 @@ func (d *IdentityDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	identity, err := d.client.GetIdentity(ctx, data.ID.ValueString())
+	var identity *ory.Identity
+	var err error
+	if !data.ExternalID.IsNull() {
+		identity, err = d.client.GetIdentityByExternalID(ctx, data.ExternalID.ValueString())
+	} else {
+		identity, err = d.client.GetIdentity(ctx, data.ID.ValueString())
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error Reading Identity", err.Error())
 		return
@@ -111,25 +138,33 @@ func (d *IdentityDataSource) Read(ctx context.Context, req datasource.ReadReques
 	data.SchemaID = types.StringValue(identity.GetSchemaId())
 	data.SchemaURL = types.StringValue(identity.GetSchemaUrl())
 	data.State = types.StringValue(identity.GetState())
+	data.ExternalID = types.StringNull()
+	if identity.ExternalId != nil && *identity.ExternalId != "" {
+		data.ExternalID = types.StringValue(*identity.ExternalId)
+	}
+	data.OrganizationID = types.StringNull()
+	if org := identity.GetOrganizationId(); org != "" {
+		data.OrganizationID = types.StringValue(org)
+	}
 
 	if identity.Traits != nil {
-		traitsJSON, err := json.Marshal(identity.Traits)
+		traitsJSON, err := helpers.NewNormalizedJSONFromObject(identity.Traits)
 		if err != nil {
 			resp.Diagnostics.AddError("Error Serializing Traits",
 				fmt.Sprintf("Could not serialize identity traits to JSON: %s", err.Error()))
 			return
 		}
-		data.Traits = types.StringValue(string(traitsJSON))
+		data.Traits = traitsJSON
 	}
 
 	if identity.MetadataPublic != nil {
-		metadataJSON, err := json.Marshal(identity.MetadataPublic)
+		metadataJSON, err := helpers.NewNormalizedJSONFromObject(identity.MetadataPublic)
 		if err != nil {
 			resp.Diagnostics.AddError("Error Serializing Metadata",
 				fmt.Sprintf("Could not serialize identity metadata_public to JSON: %s", err.Error()))
 			return
 		}
-		data.MetadataPublic = types.StringValue(string(metadataJSON))
+		data.MetadataPublic = metadataJSON
 	}
 
 	if identity.CreatedAt != nil {
@@ -27,3 +27,19 @@ func TestAccIdentityDataSource_basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccIdentityDataSource_byExternalID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/by_external_id.tf.tmpl", nil),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ory_identity.test", "id", "ory_identity.test", "id"),
+					resource.TestCheckResourceAttr("data.ory_identity.test", "external_id", "ds-legacy-user-1"),
+				),
+			},
+		},
+	})
+}
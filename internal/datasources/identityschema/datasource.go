@@ -0,0 +1,338 @@
+package identityschema
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+var (
+	_ datasource.DataSource                   = &IdentitySchemaDataSource{}
+	_ datasource.DataSourceWithConfigure      = &IdentitySchemaDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &IdentitySchemaDataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &IdentitySchemaDataSource{}
+}
+
+type IdentitySchemaDataSource struct {
+	client *client.OryClient
+}
+
+type IdentitySchemaDataSourceModel struct {
+	ID        types.String           `tfsdk:"id"`
+	Default   types.Bool             `tfsdk:"default"`
+	ProjectID types.String           `tfsdk:"project_id"`
+	Schema    helpers.NormalizedJSON `tfsdk:"schema"`
+	Traits    types.List             `tfsdk:"traits"`
+}
+
+var traitObjectAttrTypes = map[string]attr.Type{
+	"name":         types.StringType,
+	"type":         types.StringType,
+	"title":        types.StringType,
+	"format":       types.StringType,
+	"required":     types.BoolType,
+	"min_length":   types.Int64Type,
+	"max_length":   types.Int64Type,
+	"pattern":      types.StringType,
+	"identifier":   types.BoolType,
+	"credentials":  types.ListType{ElemType: types.StringType},
+	"verification": types.BoolType,
+	"recovery":     types.BoolType,
+}
+
+func (d *IdentitySchemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_schema"
+}
+
+func (d *IdentitySchemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches an identity schema with its content and trait annotations, by ID or the project's default schema.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identity schema ID to look up, e.g. 'preset://email' or the id of an ory_identity_schema. Set either id or default.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("default")),
+				},
+			},
+			"default": schema.BoolAttribute{
+				Description: "Look up the project's default identity schema. Requires workspace_api_key.",
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The project ID. If not set, uses the provider's project_id.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"schema": schema.StringAttribute{
+				Description: "The JSON Schema content (JSON string).",
+				CustomType:  helpers.NormalizedJSONType{},
+				Computed:    true,
+			},
+			"traits": schema.ListNestedAttribute{
+				Description: "The traits defined by the schema, sorted by name. Nested traits are flattened with dotted names, e.g. 'name.first'.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Trait name.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "JSON Schema type of the trait.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Label of the trait.",
+							Computed:    true,
+						},
+						"format": schema.StringAttribute{
+							Description: "JSON Schema format, e.g. 'email'.",
+							Computed:    true,
+						},
+						"required": schema.BoolAttribute{
+							Description: "Whether identities must have this trait.",
+							Computed:    true,
+						},
+						"min_length": schema.Int64Attribute{
+							Description: "Minimum length of the trait.",
+							Computed:    true,
+						},
+						"max_length": schema.Int64Attribute{
+							Description: "Maximum length of the trait.",
+							Computed:    true,
+						},
+						"pattern": schema.StringAttribute{
+							Description: "Regular expression the trait must match.",
+							Computed:    true,
+						},
+						"identifier": schema.BoolAttribute{
+							Description: "Whether the trait is a login identifier (password, code or webauthn credentials).",
+							Computed:    true,
+						},
+						"credentials": schema.ListAttribute{
+							Description: "Credential methods configured for the trait in the ory.sh/kratos extension.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"verification": schema.BoolAttribute{
+							Description: "Whether the trait is a verifiable address.",
+							Computed:    true,
+						},
+						"recovery": schema.BoolAttribute{
+							Description: "Whether the trait is a recovery address.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *IdentitySchemaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	d.client = oryClient
+}
+
+func (d *IdentitySchemaDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data IdentitySchemaDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.ID.IsUnknown() || data.Default.IsUnknown() {
+		return
+	}
+
+	if data.ID.IsNull() && !data.Default.ValueBool() {
+		resp.Diagnostics.AddError("Missing Attribute Configuration",
+			"Either 'id' or 'default = true' must be set.")
+	}
+}
+
+func (d *IdentitySchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IdentitySchemaDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := d.resolveProjectID(data.ProjectID)
+	data.ProjectID = optionalString(projectID)
+
+	var project *ory.Project
+	schemaID := data.ID.ValueString()
+	if data.Default.ValueBool() {
+		if projectID == "" {
+			resp.Diagnostics.AddError("Missing Project ID",
+				"Either specify 'project_id' in the data source or configure 'project_id' in the provider.")
+			return
+		}
+		var err error
+		project, err = d.client.GetProject(ctx, projectID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Project", err.Error())
+			return
+		}
+		identityConfig := projectIdentityConfig(project)
+		schemaID, _ = identityConfig["default_schema_id"].(string)
+		if schemaID == "" {
+			resp.Diagnostics.AddError("No Default Identity Schema",
+				fmt.Sprintf("Project %s has no default_schema_id configured.", projectID))
+			return
+		}
+	}
+
+	identitySchema, err := d.findSchema(ctx, projectID, project, schemaID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Identity Schema", err.Error())
+		return
+	}
+
+	schemaJSON, err := helpers.NewNormalizedJSONFromObject(identitySchema)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Marshaling Schema", fmt.Sprintf("Could not marshal schema %s: %s", schemaID, err.Error()))
+		return
+	}
+
+	traitObjects := []attr.Value{}
+	for _, trait := range helpers.DescribeIdentitySchemaTraits(identitySchema) {
+		credentials, diags := types.ListValueFrom(ctx, types.StringType, trait.Credentials)
+		resp.Diagnostics.Append(diags...)
+		obj, diags := types.ObjectValue(traitObjectAttrTypes, map[string]attr.Value{
+			"name":         types.StringValue(trait.Name),
+			"type":         optionalString(trait.Type),
+			"title":        optionalString(trait.Title),
+			"format":       optionalString(trait.Format),
+			"required":     types.BoolValue(trait.Required),
+			"min_length":   types.Int64PointerValue(trait.MinLength),
+			"max_length":   types.Int64PointerValue(trait.MaxLength),
+			"pattern":      optionalString(trait.Pattern),
+			"identifier":   types.BoolValue(trait.IsIdentifier()),
+			"credentials":  credentials,
+			"verification": types.BoolValue(trait.Verification),
+			"recovery":     types.BoolValue(trait.Recovery),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		traitObjects = append(traitObjects, obj)
+	}
+
+	traitList, diags := types.ListValue(types.ObjectType{AttrTypes: traitObjectAttrTypes}, traitObjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(schemaID)
+	data.Schema = schemaJSON
+	data.Traits = traitList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findSchema returns the content of the identity schema with the given ID.
+// Schemas are looked up through the project API first, then in the project
+// configuration, where schemas managed by ory_identity_schema are listed
+// under their Terraform schema_id.
+func (d *IdentitySchemaDataSource) findSchema(ctx context.Context, projectID string, project *ory.Project, schemaID string) (map[string]interface{}, error) {
+	cfg := d.client.Config()
+
+	var available []string
+	if cfg.ProjectSlug != "" && cfg.ProjectAPIKey != "" {
+		schemas, err := d.client.ListIdentitySchemas(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range schemas {
+			if s.Id == schemaID {
+				return s.Schema, nil
+			}
+			available = append(available, s.Id)
+		}
+	}
+
+	if project == nil && cfg.WorkspaceAPIKey != "" && projectID != "" {
+		var err error
+		project, err = d.client.GetProject(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if project != nil {
+		schemas, _ := projectIdentityConfig(project)["schemas"].([]interface{})
+		for _, s := range schemas {
+			entry, _ := s.(map[string]interface{})
+			if entry["id"] != schemaID {
+				continue
+			}
+			url, _ := entry["url"].(string)
+			if !strings.HasPrefix(url, "base64://") {
+				return nil, fmt.Errorf("schema %q is loaded from %s and its content is only available with project_slug and project_api_key", schemaID, url)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(url, "base64://"))
+			if err != nil {
+				return nil, fmt.Errorf("decoding schema %q: %w", schemaID, err)
+			}
+			var identitySchema map[string]interface{}
+			if err := json.Unmarshal(decoded, &identitySchema); err != nil {
+				return nil, fmt.Errorf("decoding schema %q: %w", schemaID, err)
+			}
+			return identitySchema, nil
+		}
+	}
+
+	if cfg.ProjectSlug == "" && project == nil {
+		return nil, fmt.Errorf("looking up identity schemas requires project_slug and project_api_key, or workspace_api_key and project_id")
+	}
+	return nil, fmt.Errorf("identity schema %q not found (available: %v)", schemaID, available)
+}
+
+func projectIdentityConfig(project *ory.Project) map[string]interface{} {
+	if project.Services.Identity == nil {
+		return nil
+	}
+	identityConfig, _ := project.Services.Identity.Config["identity"].(map[string]interface{})
+	return identityConfig
+}
+
+func (d *IdentitySchemaDataSource) resolveProjectID(tfProjectID types.String) string {
+	if !tfProjectID.IsNull() && !tfProjectID.IsUnknown() {
+		return tfProjectID.ValueString()
+	}
+	return d.client.ProjectID()
+}
+
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
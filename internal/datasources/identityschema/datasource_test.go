@@ -0,0 +1,55 @@
+//go:build acceptance
+
+package identityschema_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccIdentitySchemaDataSource_default(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/default.tf.tmpl", nil),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ory_identity_schema.test", "id"),
+					resource.TestCheckResourceAttrSet("data.ory_identity_schema.test", "schema"),
+					resource.TestCheckResourceAttrSet("data.ory_identity_schema.test", "traits.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentitySchemaDataSource_byID(t *testing.T) {
+	schemaID := fmt.Sprintf("tf-test-ds-schema-%d", time.Now().UnixNano())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireSchemaTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/by_id.tf.tmpl", map[string]string{"SchemaID": schemaID}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ory_identity_schema.test", "id", "ory_identity_schema.test", "id"),
+					resource.TestCheckResourceAttr("data.ory_identity_schema.test", "traits.#", "1"),
+					resource.TestCheckResourceAttr("data.ory_identity_schema.test", "traits.0.name", "email"),
+					resource.TestCheckResourceAttr("data.ory_identity_schema.test", "traits.0.identifier", "true"),
+					resource.TestCheckResourceAttr("data.ory_identity_schema.test", "traits.0.verification", "true"),
+					resource.TestCheckResourceAttr("data.ory_identity_schema.test", "traits.0.recovery", "true"),
+				),
+			},
+		},
+	})
+}
@@ -2,7 +2,6 @@ package identityschemas
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -11,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
 )
 
 var (
@@ -32,7 +32,7 @@ type IdentitySchemasDataSourceModel struct {
 
 var schemaObjectAttrTypes = map[string]attr.Type{
 	"id":     types.StringType,
-	"schema": types.StringType,
+	"schema": helpers.NormalizedJSONType{},
 }
 
 func (d *IdentitySchemasDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -83,14 +83,14 @@ func (d *IdentitySchemasDataSource) Read(ctx context.Context, req datasource.Rea
 
 	schemaObjects := make([]attr.Value, 0, len(schemas))
 	for _, s := range schemas {
-		schemaJSON, err := json.Marshal(s.GetSchema())
+		schemaJSON, err := helpers.NewNormalizedJSONFromObject(s.GetSchema())
 		if err != nil {
 			resp.Diagnostics.AddError("Error Marshaling Schema", fmt.Sprintf("Could not marshal schema %s: %s", s.GetId(), err.Error()))
 			return
 		}
 		obj, diags := types.ObjectValue(schemaObjectAttrTypes, map[string]attr.Value{
 			"id":     types.StringValue(s.GetId()),
-			"schema": types.StringValue(string(schemaJSON)),
+			"schema": schemaJSON,
 		})
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
@@ -0,0 +1,185 @@
+package identitysessions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+var (
+	_ datasource.DataSource              = &IdentitySessionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &IdentitySessionsDataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &IdentitySessionsDataSource{}
+}
+
+type IdentitySessionsDataSource struct {
+	client *client.OryClient
+}
+
+type IdentitySessionsDataSourceModel struct {
+	IdentityID types.String `tfsdk:"identity_id"`
+	ActiveOnly types.Bool   `tfsdk:"active_only"`
+	Sessions   types.List   `tfsdk:"sessions"`
+}
+
+var deviceObjectAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"ip_address": types.StringType,
+	"location":   types.StringType,
+	"user_agent": types.StringType,
+}
+
+var sessionObjectAttrTypes = map[string]attr.Type{
+	"id":                     types.StringType,
+	"active":                 types.BoolType,
+	"aal":                    types.StringType,
+	"authentication_methods": types.ListType{ElemType: types.StringType},
+	"authenticated_at":       types.StringType,
+	"issued_at":              types.StringType,
+	"expires_at":             types.StringType,
+	"devices":                types.ListType{ElemType: types.ObjectType{AttrTypes: deviceObjectAttrTypes}},
+}
+
+func (d *IdentitySessionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_sessions"
+}
+
+func (d *IdentitySessionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the sessions of an Ory identity.",
+		Attributes: map[string]schema.Attribute{
+			"identity_id": schema.StringAttribute{
+				Description: "The ID of the identity whose sessions to list.",
+				Required:    true,
+			},
+			"active_only": schema.BoolAttribute{
+				Description: "Only return active sessions (default: true).",
+				Optional:    true,
+			},
+			"sessions": schema.ListAttribute{
+				Description: "The identity's sessions. Each session has `id`, `active`, `aal` (authenticator assurance level), `authentication_methods`, `authenticated_at`, `issued_at`, `expires_at`, and `devices` (each with `id`, `ip_address`, `location`, and `user_agent`).",
+				Computed:    true,
+				ElementType: types.ObjectType{
+					AttrTypes: sessionObjectAttrTypes,
+				},
+			},
+		},
+	}
+}
+
+func (d *IdentitySessionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	d.client = oryClient
+}
+
+func (d *IdentitySessionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IdentitySessionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := d.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, &resp.Diagnostics) {
+		return
+	}
+
+	activeOnly := data.ActiveOnly.IsNull() || data.ActiveOnly.ValueBool()
+	sessions, err := d.client.ListIdentitySessions(ctx, data.IdentityID.ValueString(), activeOnly)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Listing Identity Sessions", err.Error())
+		return
+	}
+
+	sessionObjects := make([]attr.Value, 0, len(sessions))
+	for _, s := range sessions {
+		obj, diags := sessionObject(s)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sessionObjects = append(sessionObjects, obj)
+	}
+
+	sessionList, diags := types.ListValue(types.ObjectType{AttrTypes: sessionObjectAttrTypes}, sessionObjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Sessions = sessionList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func sessionObject(s ory.Session) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	methods := make([]attr.Value, 0, len(s.AuthenticationMethods))
+	for _, m := range s.AuthenticationMethods {
+		methods = append(methods, types.StringValue(m.GetMethod()))
+	}
+	methodList, d := types.ListValue(types.StringType, methods)
+	diags.Append(d...)
+
+	devices := make([]attr.Value, 0, len(s.Devices))
+	for _, device := range s.Devices {
+		obj, d := types.ObjectValue(deviceObjectAttrTypes, map[string]attr.Value{
+			"id":         types.StringValue(device.GetId()),
+			"ip_address": types.StringPointerValue(device.IpAddress),
+			"location":   types.StringPointerValue(device.Location),
+			"user_agent": types.StringPointerValue(device.UserAgent),
+		})
+		diags.Append(d...)
+		devices = append(devices, obj)
+	}
+	deviceList, d := types.ListValue(types.ObjectType{AttrTypes: deviceObjectAttrTypes}, devices)
+	diags.Append(d...)
+
+	aal := types.StringNull()
+	if s.AuthenticatorAssuranceLevel != nil {
+		aal = types.StringValue(string(*s.AuthenticatorAssuranceLevel))
+	}
+
+	obj, d := types.ObjectValue(sessionObjectAttrTypes, map[string]attr.Value{
+		"id":                     types.StringValue(s.GetId()),
+		"active":                 types.BoolValue(s.GetActive()),
+		"aal":                    aal,
+		"authentication_methods": methodList,
+		"authenticated_at":       timeValue(s.AuthenticatedAt),
+		"issued_at":              timeValue(s.IssuedAt),
+		"expires_at":             timeValue(s.ExpiresAt),
+		"devices":                deviceList,
+	})
+	diags.Append(d...)
+	return obj, diags
+}
+
+func timeValue(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
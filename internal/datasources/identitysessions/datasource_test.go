@@ -0,0 +1,27 @@
+//go:build acceptance
+
+package identitysessions_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccIdentitySessionsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", nil),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// A freshly created identity has not logged in yet
+					resource.TestCheckResourceAttr("data.ory_identity_sessions.test", "sessions.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,171 @@
+package permissioncheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+var (
+	_ datasource.DataSource              = &PermissionCheckDataSource{}
+	_ datasource.DataSourceWithConfigure = &PermissionCheckDataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &PermissionCheckDataSource{}
+}
+
+type PermissionCheckDataSource struct {
+	client *client.OryClient
+}
+
+type PermissionCheckDataSourceModel struct {
+	Namespace           types.String `tfsdk:"namespace"`
+	Object              types.String `tfsdk:"object"`
+	Relation            types.String `tfsdk:"relation"`
+	SubjectID           types.String `tfsdk:"subject_id"`
+	SubjectSetNamespace types.String `tfsdk:"subject_set_namespace"`
+	SubjectSetObject    types.String `tfsdk:"subject_set_object"`
+	SubjectSetRelation  types.String `tfsdk:"subject_set_relation"`
+	MaxDepth            types.Int64  `tfsdk:"max_depth"`
+	Allowed             types.Bool   `tfsdk:"allowed"`
+}
+
+func (d *PermissionCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_check"
+}
+
+func (d *PermissionCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	subjectSetPaths := []path.Expression{
+		path.MatchRoot("subject_set_namespace"),
+		path.MatchRoot("subject_set_object"),
+		path.MatchRoot("subject_set_relation"),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Checks whether a subject has a relation on an object using Ory Permissions (Keto).",
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Description: "The namespace of the object to check (e.g., 'documents').",
+				Required:    true,
+			},
+			"object": schema.StringAttribute{
+				Description: "The object ID in the namespace.",
+				Required:    true,
+			},
+			"relation": schema.StringAttribute{
+				Description: "The relation or permission to check (e.g., 'viewer', 'edit').",
+				Required:    true,
+			},
+			"subject_id": schema.StringAttribute{
+				Description: "The subject ID (user ID) to check. Mutually exclusive with subject_set_* attributes.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("subject_set_namespace")),
+				},
+			},
+			"subject_set_namespace": schema.StringAttribute{
+				Description: "The namespace for a subject set. Use with subject_set_object and subject_set_relation.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(subjectSetPaths...),
+				},
+			},
+			"subject_set_object": schema.StringAttribute{
+				Description: "The object ID for a subject set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(subjectSetPaths...),
+				},
+			},
+			"subject_set_relation": schema.StringAttribute{
+				Description: "The relation for a subject set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(subjectSetPaths...),
+				},
+			},
+			"max_depth": schema.Int64Attribute{
+				Description: "Maximum depth of the relationship graph to traverse. If not set, the server default is used.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"allowed": schema.BoolAttribute{
+				Description: "Whether the subject has the relation on the object.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *PermissionCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	d.client = oryClient
+}
+
+func (d *PermissionCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PermissionCheckDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := d.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, &resp.Diagnostics) {
+		return
+	}
+
+	var subjectID *string
+	var subjectSet *ory.SubjectSet
+	if !data.SubjectID.IsNull() {
+		subjectID = ory.PtrString(data.SubjectID.ValueString())
+	} else {
+		subjectSet = &ory.SubjectSet{
+			Namespace: data.SubjectSetNamespace.ValueString(),
+			Object:    data.SubjectSetObject.ValueString(),
+			Relation:  data.SubjectSetRelation.ValueString(),
+		}
+	}
+
+	var maxDepth *int64
+	if !data.MaxDepth.IsNull() {
+		v := data.MaxDepth.ValueInt64()
+		maxDepth = &v
+	}
+
+	allowed, err := d.client.CheckPermission(ctx,
+		data.Namespace.ValueString(),
+		data.Object.ValueString(),
+		data.Relation.ValueString(),
+		subjectID, subjectSet, maxDepth)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Checking Permission", err.Error())
+		return
+	}
+
+	data.Allowed = types.BoolValue(allowed)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,30 @@
+//go:build acceptance
+
+package permissioncheck_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccPermissionCheckDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireKetoTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"Object": "doc-permission-check"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ory_permission_check.allowed", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.ory_permission_check.denied", "allowed", "false"),
+				),
+			},
+		},
+	})
+}
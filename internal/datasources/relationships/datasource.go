@@ -0,0 +1,204 @@
+package relationships
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+var (
+	_ datasource.DataSource              = &RelationshipsDataSource{}
+	_ datasource.DataSourceWithConfigure = &RelationshipsDataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &RelationshipsDataSource{}
+}
+
+type RelationshipsDataSource struct {
+	client *client.OryClient
+}
+
+type RelationshipsDataSourceModel struct {
+	Namespace           types.String `tfsdk:"namespace"`
+	Object              types.String `tfsdk:"object"`
+	Relation            types.String `tfsdk:"relation"`
+	SubjectID           types.String `tfsdk:"subject_id"`
+	SubjectSetNamespace types.String `tfsdk:"subject_set_namespace"`
+	SubjectSetObject    types.String `tfsdk:"subject_set_object"`
+	SubjectSetRelation  types.String `tfsdk:"subject_set_relation"`
+	Relationships       types.List   `tfsdk:"relationships"`
+}
+
+var relationshipObjectAttrTypes = map[string]attr.Type{
+	"namespace":             types.StringType,
+	"object":                types.StringType,
+	"relation":              types.StringType,
+	"subject_id":            types.StringType,
+	"subject_set_namespace": types.StringType,
+	"subject_set_object":    types.StringType,
+	"subject_set_relation":  types.StringType,
+}
+
+func (d *RelationshipsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_relationships"
+}
+
+func (d *RelationshipsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	subjectSetPaths := []path.Expression{
+		path.MatchRoot("subject_set_namespace"),
+		path.MatchRoot("subject_set_object"),
+		path.MatchRoot("subject_set_relation"),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Queries Ory Keto relationship tuples matching a filter.",
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Description: "The namespace to query (e.g., 'groups').",
+				Required:    true,
+			},
+			"object": schema.StringAttribute{
+				Description: "Only return tuples for this object ID.",
+				Optional:    true,
+			},
+			"relation": schema.StringAttribute{
+				Description: "Only return tuples with this relation.",
+				Optional:    true,
+			},
+			"subject_id": schema.StringAttribute{
+				Description: "Only return tuples with this subject ID. Mutually exclusive with subject_set_* attributes.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(subjectSetPaths...),
+				},
+			},
+			"subject_set_namespace": schema.StringAttribute{
+				Description: "Only return tuples with this subject set namespace. Use with subject_set_object and subject_set_relation.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(subjectSetPaths...),
+				},
+			},
+			"subject_set_object": schema.StringAttribute{
+				Description: "Only return tuples with this subject set object ID.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(subjectSetPaths...),
+				},
+			},
+			"subject_set_relation": schema.StringAttribute{
+				Description: "Only return tuples with this subject set relation.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(subjectSetPaths...),
+				},
+			},
+			"relationships": schema.ListAttribute{
+				Description: "Matching relationship tuples. Each tuple has `namespace`, `object`, `relation`, and either `subject_id` or the three `subject_set_*` attributes.",
+				Computed:    true,
+				ElementType: types.ObjectType{
+					AttrTypes: relationshipObjectAttrTypes,
+				},
+			},
+		},
+	}
+}
+
+func (d *RelationshipsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	d.client = oryClient
+}
+
+func (d *RelationshipsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RelationshipsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := d.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, &resp.Diagnostics) {
+		return
+	}
+
+	query := ory.RelationQuery{
+		Namespace: ory.PtrString(data.Namespace.ValueString()),
+	}
+	if !data.Object.IsNull() {
+		query.Object = ory.PtrString(data.Object.ValueString())
+	}
+	if !data.Relation.IsNull() {
+		query.Relation = ory.PtrString(data.Relation.ValueString())
+	}
+	if !data.SubjectID.IsNull() {
+		query.SubjectId = ory.PtrString(data.SubjectID.ValueString())
+	}
+	if !data.SubjectSetNamespace.IsNull() {
+		query.SubjectSet = &ory.SubjectSet{
+			Namespace: data.SubjectSetNamespace.ValueString(),
+			Object:    data.SubjectSetObject.ValueString(),
+			Relation:  data.SubjectSetRelation.ValueString(),
+		}
+	}
+
+	rels, err := d.client.ListRelationships(ctx, query)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Listing Relationships", err.Error())
+		return
+	}
+
+	relObjects := make([]attr.Value, 0, len(rels))
+	for _, rel := range rels {
+		values := map[string]attr.Value{
+			"namespace":             types.StringValue(rel.Namespace),
+			"object":                types.StringValue(rel.Object),
+			"relation":              types.StringValue(rel.Relation),
+			"subject_id":            types.StringPointerValue(rel.SubjectId),
+			"subject_set_namespace": types.StringNull(),
+			"subject_set_object":    types.StringNull(),
+			"subject_set_relation":  types.StringNull(),
+		}
+		if rel.SubjectSet != nil {
+			values["subject_set_namespace"] = types.StringValue(rel.SubjectSet.Namespace)
+			values["subject_set_object"] = types.StringValue(rel.SubjectSet.Object)
+			values["subject_set_relation"] = types.StringValue(rel.SubjectSet.Relation)
+		}
+		obj, diags := types.ObjectValue(relationshipObjectAttrTypes, values)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		relObjects = append(relObjects, obj)
+	}
+
+	relList, diags := types.ListValue(types.ObjectType{AttrTypes: relationshipObjectAttrTypes}, relObjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Relationships = relList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
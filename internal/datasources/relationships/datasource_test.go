@@ -0,0 +1,37 @@
+//go:build acceptance
+
+package relationships_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccRelationshipsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireKetoTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"Group": "relationships-ds"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ory_relationships.test", "relationships.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ory_relationships.test", "relationships.*", map[string]string{
+						"subject_id": "user-alice",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ory_relationships.test", "relationships.*", map[string]string{
+						"subject_set_namespace": "groups",
+						"subject_set_object":    "relationships-ds-admins",
+						"subject_set_relation":  "members",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,224 @@
+package identityrecovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+const (
+	methodLink = "link"
+	methodCode = "code"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &IdentityRecoveryEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &IdentityRecoveryEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &IdentityRecoveryEphemeralResource{}
+)
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &IdentityRecoveryEphemeralResource{}
+}
+
+type IdentityRecoveryEphemeralResource struct {
+	client *client.OryClient
+}
+
+type IdentityRecoveryEphemeralResourceModel struct {
+	IdentityID   types.String `tfsdk:"identity_id"`
+	Method       types.String `tfsdk:"method"`
+	ExpiresIn    types.String `tfsdk:"expires_in"`
+	ReturnTo     types.String `tfsdk:"return_to"`
+	FlowType     types.String `tfsdk:"flow_type"`
+	RecoveryLink types.String `tfsdk:"recovery_link"`
+	RecoveryCode types.String `tfsdk:"recovery_code"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+}
+
+const identityRecoveryMarkdownDescription = `
+Creates an admin recovery link or recovery code for an Ory identity.
+
+This is an ephemeral resource: the link and code are generated each time
+Terraform opens the resource and are never persisted to the plan or state.
+Use it to feed break-glass runbooks, for example by passing the link to a
+provider or module input that accepts ephemeral values.
+
+With ` + "`method = \"link\"`" + ` (the default) the identity can recover their account by
+opening ` + "`recovery_link`" + `. With ` + "`method = \"code\"`" + ` Ory returns a one-time
+` + "`recovery_code`" + ` that must be entered on the page at ` + "`recovery_link`" + `.
+
+~> **Note:** Ephemeral resources require Terraform 1.10 or later.
+`
+
+func (r *IdentityRecoveryEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_recovery"
+}
+
+func (r *IdentityRecoveryEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Creates an admin recovery link or code for an Ory identity without storing it in state.",
+		MarkdownDescription: identityRecoveryMarkdownDescription,
+		Attributes: map[string]schema.Attribute{
+			"identity_id": schema.StringAttribute{
+				Description: "The ID of the identity to recover.",
+				Required:    true,
+			},
+			"method": schema.StringAttribute{
+				Description: "The recovery method: 'link' for a magic recovery link or 'code' for a one-time recovery code (default: 'link').",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(methodLink, methodCode),
+				},
+			},
+			"expires_in": schema.StringAttribute{
+				Description: "How long the link or code stays valid, as a Go duration (e.g., '1h', '30m', '1h30m'). Defaults to the project's recovery flow lifespan.",
+				Optional:    true,
+			},
+			"return_to": schema.StringAttribute{
+				Description: "URL to redirect the identity to after recovery. Only valid with method 'link'.",
+				Optional:    true,
+			},
+			"flow_type": schema.StringAttribute{
+				Description: "The recovery flow type the code is used with: 'browser' or 'api' (default: 'browser'). Only valid with method 'code'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("browser", "api"),
+				},
+			},
+			"recovery_link": schema.StringAttribute{
+				Description: "The recovery link. With method 'code', this is the page where the code must be entered.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"recovery_code": schema.StringAttribute{
+				Description: "The one-time recovery code. Only set with method 'code'.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "When the link or code expires (RFC3339).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *IdentityRecoveryEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	r.client = oryClient
+}
+
+func (r *IdentityRecoveryEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data IdentityRecoveryEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ExpiresIn.IsNull() && !data.ExpiresIn.IsUnknown() {
+		if d, err := time.ParseDuration(data.ExpiresIn.ValueString()); err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_in"), "Invalid Duration",
+				fmt.Sprintf("expires_in must be a positive Go duration (e.g., '1h', '30m', '1h30m'), got %q.", data.ExpiresIn.ValueString()))
+		}
+	}
+
+	if data.Method.IsUnknown() {
+		return
+	}
+
+	method := methodFor(data)
+	if method == methodLink && !data.FlowType.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("flow_type"), "Invalid Attribute Combination",
+			"flow_type can only be set when method is \"code\".")
+	}
+	if method == methodCode && !data.ReturnTo.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("return_to"), "Invalid Attribute Combination",
+			"return_to can only be set when method is \"link\".")
+	}
+}
+
+func (r *IdentityRecoveryEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	cfg := r.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, &resp.Diagnostics) {
+		return
+	}
+
+	var data IdentityRecoveryEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expiresIn *string
+	if !data.ExpiresIn.IsNull() {
+		expiresIn = ory.PtrString(data.ExpiresIn.ValueString())
+	}
+
+	switch methodFor(data) {
+	case methodCode:
+		body := ory.CreateRecoveryCodeForIdentityBody{
+			IdentityId: data.IdentityID.ValueString(),
+			ExpiresIn:  expiresIn,
+		}
+		if !data.FlowType.IsNull() {
+			body.FlowType = ory.PtrString(data.FlowType.ValueString())
+		}
+		code, err := r.client.CreateRecoveryCodeForIdentity(ctx, body)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Creating Recovery Code", err.Error())
+			return
+		}
+		data.RecoveryLink = types.StringValue(code.GetRecoveryLink())
+		data.RecoveryCode = types.StringValue(code.GetRecoveryCode())
+		data.ExpiresAt = expiresAtValue(code.ExpiresAt)
+	default:
+		body := ory.CreateRecoveryLinkForIdentityBody{
+			IdentityId: data.IdentityID.ValueString(),
+			ExpiresIn:  expiresIn,
+		}
+		link, err := r.client.CreateRecoveryLinkForIdentity(ctx, body, data.ReturnTo.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Creating Recovery Link", err.Error())
+			return
+		}
+		data.RecoveryLink = types.StringValue(link.GetRecoveryLink())
+		data.RecoveryCode = types.StringNull()
+		data.ExpiresAt = expiresAtValue(link.ExpiresAt)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func methodFor(data IdentityRecoveryEphemeralResourceModel) string {
+	if data.Method.IsNull() {
+		return methodLink
+	}
+	return data.Method.ValueString()
+}
+
+func expiresAtValue(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
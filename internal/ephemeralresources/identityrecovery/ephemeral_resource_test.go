@@ -0,0 +1,129 @@
+//go:build acceptance
+
+package identityrecovery_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+// providerFactories adds the echo provider, which copies the ephemeral
+// result into state so it can be asserted on.
+func providerFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	factories := acctest.TestAccProtoV6ProviderFactories()
+	factories["echo"] = echoprovider.NewProviderServer()
+	return factories
+}
+
+func TestAccIdentityRecoveryEphemeralResource_link(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: providerFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{
+					"Email":  fmt.Sprintf("recovery-link-%d@example.com", time.Now().UnixNano()),
+					"Method": "link",
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("recovery_link"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^https://.+`))),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("recovery_code"),
+						knownvalue.Null()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_at"),
+						knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccIdentityRecoveryEphemeralResource_code(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: providerFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{
+					"Email":  fmt.Sprintf("recovery-code-%d@example.com", time.Now().UnixNano()),
+					"Method": "code",
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("recovery_code"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^\d+$`))),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("recovery_link"),
+						knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccIdentityRecoveryEphemeralResource_invalidCombination(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_combination.tf.tmpl", nil),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`flow_type can only be set when method is "code"`),
+			},
+		},
+	})
+}
+
+func TestAccIdentityRecoveryEphemeralResource_invalidExpiresIn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_expires_in.tf.tmpl", nil),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expires_in must be a positive Go duration`),
+			},
+		},
+	})
+}
+
+func TestAccIdentityRecoveryEphemeralResource_missingCredentials(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/missing_credentials.tf.tmpl", nil),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Project Credentials`),
+			},
+		},
+	})
+}
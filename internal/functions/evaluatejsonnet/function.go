@@ -0,0 +1,69 @@
+package evaluatejsonnet
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+var _ function.Function = &EvaluateJsonnetFunction{}
+
+func NewFunction() function.Function {
+	return &EvaluateJsonnetFunction{}
+}
+
+// EvaluateJsonnetFunction evaluates Jsonnet locally, so webhook bodies and
+// claims mappers can be tested without running an Ory flow.
+type EvaluateJsonnetFunction struct{}
+
+func (f *EvaluateJsonnetFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "evaluate_jsonnet"
+}
+
+func (f *EvaluateJsonnetFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Evaluates a Jsonnet snippet against a sample context.",
+		Description: "Evaluates a Jsonnet snippet, such as a webhook body or a claims mapper, and returns the resulting JSON document. " +
+			"Each top-level key of vars is available as std.extVar(key), and function parameters (e.g. ctx in function(ctx) ...) are bound to the vars of the same name. " +
+			"The snippet may also be a base64:// URL.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "snippet",
+				Description: "Jsonnet code, or a base64:// URL of Jsonnet code.",
+			},
+			function.StringParameter{
+				Name:        "vars",
+				Description: "JSON object of sample variables, e.g. jsonencode({ ctx = { identity = { ... } } }).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EvaluateJsonnetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var snippet, vars string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &snippet, &vars))
+	if resp.Error != nil {
+		return
+	}
+
+	source, ok, err := helpers.JsonnetSource(snippet)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, "Jsonnet referenced by URL cannot be evaluated; pass the code or a base64:// URL")
+		return
+	}
+
+	result, err := helpers.EvaluateJsonnet(source, vars)
+	if err != nil {
+		resp.Error = function.NewFuncError("Error evaluating Jsonnet: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
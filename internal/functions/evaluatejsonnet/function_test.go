@@ -0,0 +1,47 @@
+//go:build acceptance
+
+package evaluatejsonnet_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+// The function runs locally, so these tests need no Ory project.
+
+func TestAccEvaluateJsonnetFunction_webhookBody(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/webhook_body.tf.tmpl", map[string]string{"IdentityID": "9f425a8d-7efc-4768-8f23-7647a74fdf13"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("body", `{"email":"user@example.com","identity_id":"9f425a8d-7efc-4768-8f23-7647a74fdf13"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEvaluateJsonnetFunction_error(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid.tf.tmpl", nil),
+				ExpectError: regexp.MustCompile(`Field does not exist: traits`),
+			},
+		},
+	})
+}
@@ -0,0 +1,109 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// hashedPasswordFormat describes a password hash format accepted by Ory
+// Kratos for identity import.
+type hashedPasswordFormat struct {
+	name   string
+	prefix *regexp.Regexp
+	full   *regexp.Regexp
+	hint   string
+}
+
+// hashedPasswordFormats lists the formats Kratos can import. prefix
+// identifies the algorithm; full validates the structure where it is
+// well-defined.
+var hashedPasswordFormats = []hashedPasswordFormat{
+	{
+		name:   "bcrypt",
+		prefix: regexp.MustCompile(`^\$2[abxy]?\$`),
+		full:   regexp.MustCompile(`^\$2[abxy]?\$\d{2}\$[./A-Za-z0-9]{53}$`),
+		hint:   "$2a$<cost>$<53 characters of salt and hash>",
+	},
+	{
+		name:   "argon2",
+		prefix: regexp.MustCompile(`^\$argon2(id|i)\$`),
+		full:   regexp.MustCompile(`^\$argon2(id|i)\$v=\d+\$m=\d+,t=\d+,p=\d+\$[A-Za-z0-9+/=]+\$[A-Za-z0-9+/=]+$`),
+		hint:   "$argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>",
+	},
+	{
+		name:   "pbkdf2",
+		prefix: regexp.MustCompile(`^\$pbkdf2-`),
+		full:   regexp.MustCompile(`^\$pbkdf2-(sha1|sha224|sha256|sha384|sha512)\$i=\d+,l=\d+\$[^$]+\$[^$]+$`),
+		hint:   "$pbkdf2-sha256$i=<iterations>,l=<key length>$<salt>$<hash>",
+	},
+	{
+		name:   "scrypt",
+		prefix: regexp.MustCompile(`^\$scrypt\$`),
+		full:   regexp.MustCompile(`^\$scrypt\$ln=\d+,r=\d+,p=\d+\$[^$]+\$[^$]+$`),
+		hint:   "$scrypt$ln=<cost>,r=<block size>,p=<parallelism>$<salt>$<hash>",
+	},
+	{
+		name:   "firebase scrypt",
+		prefix: regexp.MustCompile(`^\$firescrypt\$`),
+		full:   regexp.MustCompile(`^\$firescrypt\$ln=\d+,r=\d+,p=\d+\$[^$]+\$[^$]+\$[^$]+\$[^$]+$`),
+		hint:   "$firescrypt$ln=<rounds>,r=<block size>,p=<parallelism>$<hash>$<salt>$<salt separator>$<signer key>",
+	},
+	{
+		name:   "salted SHA",
+		prefix: regexp.MustCompile(`^\$sha(1|256|512)\$`),
+		full:   regexp.MustCompile(`^\$sha(1|256|512)\$pf=[^$]+\$[^$]*\$[^$]+$`),
+		hint:   "$sha256$pf=<pattern>$<salt>$<hash>",
+	},
+	{
+		name:   "SSHA",
+		prefix: regexp.MustCompile(`^\{SSHA(256|512)?\}`),
+		full:   regexp.MustCompile(`^\{SSHA(256|512)?\}[A-Za-z0-9+/=]+$`),
+		hint:   "{SSHA256}<base64 hash and salt>",
+	},
+	{
+		name:   "MD5",
+		prefix: regexp.MustCompile(`^\$md5\$`),
+		full:   regexp.MustCompile(`^\$md5\$(pf=[^$]+\$[^$]*\$)?[^$]+$`),
+		hint:   "$md5$<base64 hash>",
+	},
+	{
+		name:   "MD5 crypt",
+		prefix: regexp.MustCompile(`^\$md5-crypt\$`),
+		full:   regexp.MustCompile(`^\$md5-crypt\$[^$]+\$[^$]+$`),
+		hint:   "$md5-crypt$<salt>$<hash>",
+	},
+	{
+		name:   "SHA crypt",
+		prefix: regexp.MustCompile(`^\$sha(256|512)-crypt\$`),
+		full:   regexp.MustCompile(`^\$sha(256|512)-crypt\$(rounds=\d+\$)?[^$]+\$[^$]+$`),
+		hint:   "$sha512-crypt$rounds=<rounds>$<salt>$<hash>",
+	},
+	{
+		name:   "HMAC",
+		prefix: regexp.MustCompile(`^\$hmac-`),
+		full:   regexp.MustCompile(`^\$hmac-(md4|md5|sha1|sha224|sha256|sha384|sha512)\$[^$]+\$[^$]+$`),
+		hint:   "$hmac-sha256$<base64 hash>$<base64 key>",
+	},
+}
+
+// ValidateHashedPassword checks that a password hash is in one of the
+// formats Ory Kratos accepts for import (bcrypt, argon2, pbkdf2, scrypt,
+// firebase scrypt, salted SHA, SSHA, MD5, MD5/SHA crypt, HMAC).
+func ValidateHashedPassword(hash string) error {
+	for _, f := range hashedPasswordFormats {
+		if !f.prefix.MatchString(hash) {
+			continue
+		}
+		if !f.full.MatchString(hash) {
+			return fmt.Errorf("malformed %s hash: expected format %s", f.name, f.hint)
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(hashedPasswordFormats))
+	for _, f := range hashedPasswordFormats {
+		names = append(names, f.name)
+	}
+	return fmt.Errorf("unsupported password hash format; supported formats are %s", strings.Join(names, ", "))
+}
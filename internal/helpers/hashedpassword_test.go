@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateHashedPassword_Valid(t *testing.T) {
+	hashes := []string{
+		"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy",
+		"$2y$12$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy",
+		"$argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHQ$aGFzaGhhc2hoYXNo",
+		"$argon2i$v=19$m=4096,t=3,p=1$c2FsdA$aGFzaA",
+		"$pbkdf2-sha256$i=100000,l=32$c2FsdA$aGFzaA",
+		"$pbkdf2-sha512$i=10000,l=64$c2FsdA$aGFzaA",
+		"$scrypt$ln=16384,r=8,p=1$c2FsdA$aGFzaA",
+		"$firescrypt$ln=14,r=8,p=1$aGFzaA$c2FsdA$Bw==$c2lnbmVy",
+		"$sha256$pf=e1NBTFR9e1BBU1NXT1JEfQ==$c2FsdA$aGFzaA",
+		"{SSHA256}aGFzaHNhbHQ=",
+		"$md5$aGFzaA==",
+		"$sha512-crypt$rounds=5000$salt$hash",
+		"$hmac-sha256$aGFzaA==$a2V5",
+	}
+
+	for _, h := range hashes {
+		t.Run(h, func(t *testing.T) {
+			if err := ValidateHashedPassword(h); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHashedPassword_Invalid(t *testing.T) {
+	tests := []struct {
+		hash    string
+		message string
+	}{
+		{"plaintext-password", "unsupported password hash format"},
+		{"", "unsupported password hash format"},
+		{"$2a$10$tooshort", "malformed bcrypt hash"},
+		{"$argon2id$m=65536,t=3,p=4$salt$hash", "malformed argon2 hash"},
+		{"$pbkdf2-md5$i=1000,l=32$salt$hash", "malformed pbkdf2 hash"},
+		{"$scrypt$salt$hash", "malformed scrypt hash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hash, func(t *testing.T) {
+			err := ValidateHashedPassword(tt.hash)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("expected error containing %q, got %q", tt.message, err.Error())
+			}
+		})
+	}
+}
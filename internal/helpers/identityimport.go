@@ -0,0 +1,367 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	ory "github.com/ory/client-go"
+)
+
+// Identity import file formats.
+const (
+	IdentityImportFormatJSONL = "jsonl"
+	IdentityImportFormatCSV   = "csv"
+)
+
+// IdentityImportRecord is a single identity read from a bulk import file.
+type IdentityImportRecord struct {
+	// Key identifies the record across applies. It is the external ID, so
+	// edits to the traits or credentials of a record update its identity.
+	Key string
+	// Line is the 1-based line of the record in the source file.
+	Line int
+	// Hash is a digest of the record content, used to detect changes. It
+	// leaves out passwords and password hashes, as it is stored in state.
+	Hash string
+	Body ory.CreateIdentityBody
+}
+
+// IdentityImportError describes a record that could not be parsed.
+type IdentityImportError struct {
+	Line    int
+	Message string
+}
+
+func (e IdentityImportError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// identityImportJSON is the JSONL record format. It follows the Ory
+// identity import payload.
+type identityImportJSON struct {
+	ExternalID     *string                      `json:"external_id,omitempty"`
+	SchemaID       string                       `json:"schema_id"`
+	State          *string                      `json:"state,omitempty"`
+	Traits         map[string]interface{}       `json:"traits"`
+	MetadataPublic interface{}                  `json:"metadata_public,omitempty"`
+	MetadataAdmin  interface{}                  `json:"metadata_admin,omitempty"`
+	Credentials    *ory.IdentityWithCredentials `json:"credentials,omitempty"`
+}
+
+// IdentityImportFormatFromPath infers the import format from a file name.
+// Files ending in .csv are CSV; everything else is treated as JSONL.
+func IdentityImportFormatFromPath(p string) string {
+	if strings.HasSuffix(strings.ToLower(p), ".csv") {
+		return IdentityImportFormatCSV
+	}
+	return IdentityImportFormatJSONL
+}
+
+// ParseIdentityImportFile parses a JSONL or CSV identity import file.
+// Records without a schema_id use defaultSchemaID. Every record needs an
+// external_id. All invalid records are reported, not just the first.
+func ParseIdentityImportFile(data []byte, format, defaultSchemaID string) ([]IdentityImportRecord, []IdentityImportError) {
+	var (
+		raw  []identityImportJSON
+		errs []IdentityImportError
+		line []int
+	)
+
+	switch format {
+	case IdentityImportFormatJSONL:
+		raw, line, errs = parseIdentityJSONL(data)
+	case IdentityImportFormatCSV:
+		raw, line, errs = parseIdentityCSV(data)
+	default:
+		return nil, []IdentityImportError{{Line: 0, Message: fmt.Sprintf("unsupported format %q, expected jsonl or csv", format)}}
+	}
+
+	records := make([]IdentityImportRecord, 0, len(raw))
+	seen := make(map[string]int, len(raw))
+	for i, r := range raw {
+		if r.SchemaID == "" {
+			r.SchemaID = defaultSchemaID
+		}
+		if err := validateIdentityImportRecord(r); err != nil {
+			errs = append(errs, IdentityImportError{Line: line[i], Message: err.Error()})
+			continue
+		}
+
+		content, err := json.Marshal(withoutPasswords(r))
+		if err != nil {
+			errs = append(errs, IdentityImportError{Line: line[i], Message: err.Error()})
+			continue
+		}
+		hash := sha256Hex(content)
+
+		key := *r.ExternalID
+		if prev, ok := seen[key]; ok {
+			errs = append(errs, IdentityImportError{
+				Line:    line[i],
+				Message: fmt.Sprintf("duplicate external_id %q, already used on line %d", key, prev),
+			})
+			continue
+		}
+		seen[key] = line[i]
+
+		body := ory.CreateIdentityBody{
+			ExternalId:     r.ExternalID,
+			SchemaId:       r.SchemaID,
+			State:          r.State,
+			Traits:         r.Traits,
+			MetadataPublic: r.MetadataPublic,
+			MetadataAdmin:  r.MetadataAdmin,
+			Credentials:    r.Credentials,
+		}
+		records = append(records, IdentityImportRecord{Key: key, Line: line[i], Hash: hash, Body: body})
+	}
+
+	return records, errs
+}
+
+func validateIdentityImportRecord(r identityImportJSON) error {
+	if r.SchemaID == "" {
+		return errors.New("schema_id is required (set it on the record or as the resource default)")
+	}
+	if r.Traits == nil {
+		return errors.New("traits are required")
+	}
+	if r.ExternalID == nil || *r.ExternalID == "" {
+		return errors.New("external_id is required, it identifies the record across applies")
+	}
+	if r.State != nil && *r.State != "active" && *r.State != "inactive" {
+		return fmt.Errorf("state must be active or inactive, got %q", *r.State)
+	}
+	if r.Credentials != nil && r.Credentials.Password != nil && r.Credentials.Password.Config != nil {
+		if hash := r.Credentials.Password.Config.HashedPassword; hash != nil {
+			if err := ValidateHashedPassword(*hash); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseIdentityJSONL(data []byte) ([]identityImportJSON, []int, []IdentityImportError) {
+	var (
+		records []identityImportJSON
+		lines   []int
+		errs    []IdentityImportError
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var r identityImportJSON
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&r); err != nil {
+			errs = append(errs, IdentityImportError{Line: n, Message: "invalid JSON: " + err.Error()})
+			continue
+		}
+		records = append(records, r)
+		lines = append(lines, n)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, IdentityImportError{Line: n + 1, Message: err.Error()})
+	}
+
+	return records, lines, errs
+}
+
+// parseIdentityCSV parses a CSV file with a header row. Recognized columns
+// are external_id, schema_id, state, password, hashed_password, traits,
+// metadata_public and metadata_admin (the last three as JSON), plus
+// traits.<path> columns which set a single string trait. Empty cells are
+// ignored.
+func parseIdentityCSV(data []byte) ([]identityImportJSON, []int, []IdentityImportError) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 0
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, []IdentityImportError{{Line: 1, Message: "invalid CSV header: " + err.Error()}}
+	}
+
+	for _, col := range header {
+		switch col {
+		case "external_id", "schema_id", "state", "password", "hashed_password", "traits", "metadata_public", "metadata_admin":
+		default:
+			if !strings.HasPrefix(col, "traits.") || strings.TrimPrefix(col, "traits.") == "" {
+				return nil, nil, []IdentityImportError{{Line: 1, Message: fmt.Sprintf("unknown column %q", col)}}
+			}
+		}
+	}
+
+	var (
+		records []identityImportJSON
+		lines   []int
+		errs    []IdentityImportError
+	)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// FieldPos is only valid after a successful Read.
+			line := bytes.Count(data[:reader.InputOffset()], []byte("\n")) + 1
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.Line
+			}
+			errs = append(errs, IdentityImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		r, err := identityFromCSVRow(header, row)
+		if err != nil {
+			errs = append(errs, IdentityImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		records = append(records, r)
+		lines = append(lines, line)
+	}
+
+	return records, lines, errs
+}
+
+func identityFromCSVRow(header, row []string) (identityImportJSON, error) {
+	var r identityImportJSON
+	var password ory.IdentityWithCredentialsPasswordConfig
+
+	for i, col := range header {
+		value := row[i]
+		if value == "" {
+			continue
+		}
+
+		switch col {
+		case "external_id":
+			r.ExternalID = ory.PtrString(value)
+		case "schema_id":
+			r.SchemaID = value
+		case "state":
+			r.State = ory.PtrString(value)
+		case "password":
+			password.Password = ory.PtrString(value)
+		case "hashed_password":
+			password.HashedPassword = ory.PtrString(value)
+		case "traits":
+			if err := json.Unmarshal([]byte(value), &r.Traits); err != nil {
+				return r, fmt.Errorf("column traits: invalid JSON: %w", err)
+			}
+		case "metadata_public":
+			if err := json.Unmarshal([]byte(value), &r.MetadataPublic); err != nil {
+				return r, fmt.Errorf("column metadata_public: invalid JSON: %w", err)
+			}
+		case "metadata_admin":
+			if err := json.Unmarshal([]byte(value), &r.MetadataAdmin); err != nil {
+				return r, fmt.Errorf("column metadata_admin: invalid JSON: %w", err)
+			}
+		default:
+			if r.Traits == nil {
+				r.Traits = map[string]interface{}{}
+			}
+			if err := setTraitPath(r.Traits, strings.Split(strings.TrimPrefix(col, "traits."), "."), value); err != nil {
+				return r, fmt.Errorf("column %s: %w", col, err)
+			}
+		}
+	}
+
+	if password.Password != nil && password.HashedPassword != nil {
+		return r, errors.New("password and hashed_password are mutually exclusive")
+	}
+	if password.Password != nil || password.HashedPassword != nil {
+		r.Credentials = &ory.IdentityWithCredentials{
+			Password: &ory.IdentityWithCredentialsPassword{Config: &password},
+		}
+	}
+
+	return r, nil
+}
+
+// setTraitPath sets a nested trait, creating intermediate objects.
+func setTraitPath(traits map[string]interface{}, parts []string, value string) error {
+	current := traits
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part]
+		if !ok {
+			child := map[string]interface{}{}
+			current[part] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("trait %q is not an object", part)
+		}
+		current = child
+	}
+
+	last := parts[len(parts)-1]
+	if _, ok := current[last]; ok {
+		return fmt.Errorf("trait %q is set more than once", last)
+	}
+	current[last] = value
+	return nil
+}
+
+// IdentityImportContentHash returns the digest used to detect changes to
+// an import file. It covers the keys and hashes of the parsed records, so
+// like the record hashes it does not depend on any password.
+func IdentityImportContentHash(records []IdentityImportRecord) string {
+	var b strings.Builder
+	for _, r := range records {
+		key, _ := json.Marshal(r.Key)
+		fmt.Fprintf(&b, "%s %s\n", key, r.Hash)
+	}
+	return sha256Hex([]byte(b.String()))
+}
+
+// withoutPasswords returns a copy of r with the password and password hash
+// blanked out, keeping only whether they are set. Record hashes are stored
+// in state, and a digest over a plaintext password could be brute-forced
+// offline.
+func withoutPasswords(r identityImportJSON) identityImportJSON {
+	if r.Credentials == nil || r.Credentials.Password == nil || r.Credentials.Password.Config == nil {
+		return r
+	}
+	config := *r.Credentials.Password.Config
+	if config.Password != nil {
+		config.Password = ory.PtrString("")
+	}
+	if config.HashedPassword != nil {
+		config.HashedPassword = ory.PtrString("")
+	}
+	password := *r.Credentials.Password
+	password.Config = &config
+	credentials := *r.Credentials
+	credentials.Password = &password
+	r.Credentials = &credentials
+	return r
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
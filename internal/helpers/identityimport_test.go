@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseIdentityImportFile_JSONL(t *testing.T) {
+	data := []byte(`{"external_id":"u-1","schema_id":"preset://email","traits":{"email":"a@example.com"},"credentials":{"password":{"config":{"hashed_password":"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"}}}}
+
+{"external_id":"u-2","traits":{"email":"b@example.com"},"state":"inactive"}
+`)
+
+	records, errs := ParseIdentityImportFile(data, IdentityImportFormatJSONL, "default")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	if records[0].Key != "u-1" || records[0].Line != 1 {
+		t.Errorf("unexpected first record key/line: %q/%d", records[0].Key, records[0].Line)
+	}
+	if records[0].Body.Credentials.Password.Config.GetHashedPassword() == "" {
+		t.Error("expected hashed password to be set")
+	}
+
+	if records[1].Key != "u-2" || records[1].Line != 3 {
+		t.Errorf("unexpected second record key/line: %q/%d", records[1].Key, records[1].Line)
+	}
+	if records[1].Body.SchemaId != "default" {
+		t.Errorf("expected default schema_id, got %q", records[1].Body.SchemaId)
+	}
+}
+
+func TestParseIdentityImportFile_CSV(t *testing.T) {
+	data := []byte(`external_id,traits.email,traits.name.first,hashed_password,metadata_public
+u-1,a@example.com,Alice,$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy,"{""plan"":""pro""}"
+u-2,b@example.com,,,
+`)
+
+	records, errs := ParseIdentityImportFile(data, IdentityImportFormatCSV, "preset://email")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	name, ok := records[0].Body.Traits["name"].(map[string]interface{})
+	if !ok || name["first"] != "Alice" {
+		t.Errorf("expected nested trait name.first, got %v", records[0].Body.Traits)
+	}
+	if records[0].Body.MetadataPublic == nil {
+		t.Error("expected metadata_public to be set")
+	}
+	if records[1].Body.Credentials != nil {
+		t.Error("expected no credentials for empty password columns")
+	}
+	if records[1].Line != 3 {
+		t.Errorf("expected line 3, got %d", records[1].Line)
+	}
+}
+
+func TestParseIdentityImportFile_HashChangesWithContent(t *testing.T) {
+	a, _ := ParseIdentityImportFile([]byte(`{"external_id":"u-1","schema_id":"s","traits":{"email":"a@example.com"}}`), IdentityImportFormatJSONL, "")
+	b, _ := ParseIdentityImportFile([]byte(`{"external_id":"u-1","schema_id":"s","traits":{"email":"b@example.com"}}`), IdentityImportFormatJSONL, "")
+	c, _ := ParseIdentityImportFile([]byte(`{"traits":{"email":"a@example.com"},"schema_id":"s","external_id":"u-1"}`), IdentityImportFormatJSONL, "")
+
+	if a[0].Key != b[0].Key {
+		t.Error("expected records with the same external_id to share a key")
+	}
+	if a[0].Hash == b[0].Hash {
+		t.Error("expected different content to produce different hashes")
+	}
+	if a[0].Hash != c[0].Hash {
+		t.Error("expected field order not to affect the hash")
+	}
+}
+
+func TestParseIdentityImportFile_HashExcludesPasswords(t *testing.T) {
+	a, _ := ParseIdentityImportFile([]byte(`{"external_id":"u-1","schema_id":"s","traits":{"email":"a@example.com"},"credentials":{"password":{"config":{"password":"hunter2"}}}}`), IdentityImportFormatJSONL, "")
+	b, _ := ParseIdentityImportFile([]byte(`{"external_id":"u-1","schema_id":"s","traits":{"email":"a@example.com"},"credentials":{"password":{"config":{"password":"correct horse"}}}}`), IdentityImportFormatJSONL, "")
+	c, _ := ParseIdentityImportFile([]byte(`{"external_id":"u-1","schema_id":"s","traits":{"email":"a@example.com"}}`), IdentityImportFormatJSONL, "")
+
+	if a[0].Hash != b[0].Hash {
+		t.Error("expected the password not to affect the hash")
+	}
+	if a[0].Hash == c[0].Hash {
+		t.Error("expected setting a password to change the hash")
+	}
+	if a[0].Body.Credentials.Password.Config.GetPassword() != "hunter2" {
+		t.Error("expected the password to be kept in the record body")
+	}
+	if IdentityImportContentHash(a) != IdentityImportContentHash(b) {
+		t.Error("expected the password not to affect the content hash")
+	}
+}
+
+func TestParseIdentityImportFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		data    string
+		line    int
+		message string
+	}{
+		{"bad json", IdentityImportFormatJSONL, `{"traits":`, 1, "invalid JSON"},
+		{"unknown field", IdentityImportFormatJSONL, `{"schema_id":"s","traits":{},"email":"x"}`, 1, "unknown field"},
+		{"missing schema", IdentityImportFormatJSONL, `{"traits":{}}`, 1, "schema_id is required"},
+		{"missing traits", IdentityImportFormatJSONL, `{"schema_id":"s"}`, 1, "traits are required"},
+		{"bad state", IdentityImportFormatJSONL, `{"external_id":"u","schema_id":"s","traits":{},"state":"deleted"}`, 1, "state must be active or inactive"},
+		{"bad hash", IdentityImportFormatJSONL, `{"external_id":"u","schema_id":"s","traits":{},"credentials":{"password":{"config":{"hashed_password":"plain"}}}}`, 1, "unsupported password hash format"},
+		{"duplicate", IdentityImportFormatJSONL, "{\"external_id\":\"u\",\"schema_id\":\"s\",\"traits\":{}}\n{\"external_id\":\"u\",\"schema_id\":\"s\",\"traits\":{}}", 2, `duplicate external_id "u", already used on line 1`},
+		{"missing external_id", IdentityImportFormatJSONL, `{"schema_id":"s","traits":{}}`, 1, "external_id is required"},
+		{"unknown column", IdentityImportFormatCSV, "email\na@example.com\n", 1, `unknown column "email"`},
+		{"both passwords", IdentityImportFormatCSV, "schema_id,traits.email,password,hashed_password\ns,a@example.com,secret,$md5$aGFzaA==\n", 2, "mutually exclusive"},
+		{"bad traits json", IdentityImportFormatCSV, "schema_id,traits\ns,{\n", 2, "column traits: invalid JSON"},
+		{"unterminated quote", IdentityImportFormatCSV, "external_id,schema_id\n\"x,1\n", 2, "extraneous or missing \" in quoted-field"},
+		{"bare quote", IdentityImportFormatCSV, "external_id,schema_id\nx\"y,1\n", 2, "bare \" in non-quoted-field"},
+		{"unsupported format", "xml", "", 0, "unsupported format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ParseIdentityImportFile([]byte(tt.data), tt.format, "")
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %v", errs)
+			}
+			if errs[0].Line != tt.line {
+				t.Errorf("expected line %d, got %d", tt.line, errs[0].Line)
+			}
+			if !strings.Contains(errs[0].Message, tt.message) {
+				t.Errorf("expected error containing %q, got %q", tt.message, errs[0].Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = NormalizedJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = NormalizedJSON{}
+	_ xattr.ValidateableAttribute                = NormalizedJSON{}
+)
+
+// NormalizedJSONType is a string attribute type holding a JSON document.
+// Values that differ only in key order, whitespace or number formatting are
+// semantically equal, so API normalisation and the choice between
+// jsonencode() and file() do not cause diffs.
+type NormalizedJSONType struct {
+	basetypes.StringType
+}
+
+func (t NormalizedJSONType) String() string {
+	return "helpers.NormalizedJSONType"
+}
+
+func (t NormalizedJSONType) ValueType(ctx context.Context) attr.Value {
+	return NormalizedJSON{}
+}
+
+func (t NormalizedJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(NormalizedJSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t NormalizedJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return NormalizedJSON{StringValue: in}, nil
+}
+
+func (t NormalizedJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	value, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return value, nil
+}
+
+// NormalizedJSON is a value of NormalizedJSONType.
+type NormalizedJSON struct {
+	basetypes.StringValue
+}
+
+// NewNormalizedJSONValue returns a known NormalizedJSON holding s.
+func NewNormalizedJSONValue(s string) NormalizedJSON {
+	return NormalizedJSON{StringValue: basetypes.NewStringValue(s)}
+}
+
+// NewNormalizedJSONNull returns a null NormalizedJSON.
+func NewNormalizedJSONNull() NormalizedJSON {
+	return NormalizedJSON{StringValue: basetypes.NewStringNull()}
+}
+
+// NewNormalizedJSONUnknown returns an unknown NormalizedJSON.
+func NewNormalizedJSONUnknown() NormalizedJSON {
+	return NormalizedJSON{StringValue: basetypes.NewStringUnknown()}
+}
+
+// NewNormalizedJSONFromObject encodes v as a known NormalizedJSON.
+func NewNormalizedJSONFromObject(v interface{}) (NormalizedJSON, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return NewNormalizedJSONNull(), err
+	}
+	return NewNormalizedJSONValue(string(raw)), nil
+}
+
+func (v NormalizedJSON) Type(ctx context.Context) attr.Type {
+	return NormalizedJSONType{}
+}
+
+func (v NormalizedJSON) Equal(o attr.Value) bool {
+	other, ok := o.(NormalizedJSON)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values encode the same JSON
+// document. The framework uses it to keep the prior value when the API
+// returns a reformatted but equivalent document.
+func (v NormalizedJSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(NormalizedJSON)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this to the provider developers.", v, newValuable))
+		return false, diags
+	}
+
+	return JSONSemanticallyEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+func (v NormalizedJSON) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if !json.Valid([]byte(v.ValueString())) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON String Value",
+			"A string value was provided that is not valid JSON.\n\nGiven Value: "+v.ValueString())
+	}
+}
+
+// Unmarshal decodes the JSON document into target.
+func (v NormalizedJSON) Unmarshal(target interface{}) error {
+	return json.Unmarshal([]byte(v.ValueString()), target)
+}
+
+// NormalizeJSON returns the canonical form of a JSON document: compact, with
+// object keys sorted and numbers in their shortest form ("1.0" and "1e0"
+// become "1"), matching how the Ory APIs echo documents back. Integers
+// beyond float64 precision keep all their digits.
+func NormalizeJSON(s string) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+	if dec.More() {
+		return "", fmt.Errorf("unexpected data after JSON document")
+	}
+	out, err := json.Marshal(normalizeNumbers(v))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// normalizeNumbers rewrites the json.Number values of a decoded document
+// into their shortest form. Integers that float64 cannot represent exactly
+// are kept as exact decimal strings so that distinct IDs never compare equal.
+func normalizeNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = normalizeNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = normalizeNumbers(e)
+		}
+	case json.Number:
+		f, err := strconv.ParseFloat(t.String(), 64)
+		if err != nil {
+			return t
+		}
+		if f == math.Trunc(f) && math.Abs(f) >= 1<<53 {
+			if r, ok := new(big.Rat).SetString(t.String()); ok && r.IsInt() {
+				return json.Number(r.Num().String())
+			}
+		}
+		return f
+	}
+	return v
+}
+
+// JSONSemanticallyEqual reports whether a and b are the same JSON document.
+// Invalid JSON is only equal to the identical string.
+func JSONSemanticallyEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	na, err := NormalizeJSON(a)
+	if err != nil {
+		return false
+	}
+	nb, err := NormalizeJSON(b)
+	if err != nil {
+		return false
+	}
+	return na == nb
+}
+
+// RequiresReplaceIfJSONChanged is a RequiresReplace plan modifier for
+// NormalizedJSON attributes that ignores changes which only reformat the
+// document.
+func RequiresReplaceIfJSONChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !JSONSemanticallyEqual(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"If the JSON document changes, Terraform will destroy and recreate the resource.",
+		"If the JSON document changes, Terraform will destroy and recreate the resource.",
+	)
+}
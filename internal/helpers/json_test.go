@@ -0,0 +1,100 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestNormalizeJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"b": 1, "a": [1, 2]}`, `{"a":[1,2],"b":1}`},
+		{"{\n  \"a\": {\"d\": true, \"c\": null}\n}\n", `{"a":{"c":null,"d":true}}`},
+		{`{"n": 1.0, "m": 1e3}`, `{"m":1000,"n":1}`},
+		{`"plain"`, `"plain"`},
+		{`{"id": 9007199254740993}`, `{"id":9007199254740993}`},
+		{`{"id": 1.2345678901234567891e19}`, `{"id":12345678901234567891}`},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeJSON(tt.in)
+		if err != nil {
+			t.Fatalf("NormalizeJSON(%q): unexpected error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeJSON(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{`{"a":`, `{} {}`, ``} {
+		if _, err := NormalizeJSON(in); err == nil {
+			t.Errorf("NormalizeJSON(%q): expected error", in)
+		}
+	}
+}
+
+func TestNormalizedJSON_SemanticEquals(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{"identical", `{"a":1}`, `{"a":1}`, true},
+		{"key order and whitespace", `{"email": "a@example.com", "name": {"last": "B", "first": "A"}}`, `{"name":{"first":"A","last":"B"},"email":"a@example.com"}`, true},
+		{"number format", `{"age": 30.0}`, `{"age":30}`, true},
+		{"different value", `{"a":1}`, `{"a":2}`, false},
+		{"large integers", `{"id":9007199254740993}`, `{"id":9007199254740992}`, false},
+		{"array order matters", `[1,2]`, `[2,1]`, false},
+		{"invalid JSON", `{"a":1`, `{"a":1}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := NewNormalizedJSONValue(tt.a).StringSemanticEquals(context.Background(), NewNormalizedJSONValue(tt.b))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != tt.equal {
+				t.Errorf("expected semantic equality %v, got %v", tt.equal, equal)
+			}
+		})
+	}
+}
+
+func TestNormalizedJSON_ValidateAttribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   NormalizedJSON
+		invalid bool
+	}{
+		{"valid", NewNormalizedJSONValue(`{"a":1}`), false},
+		{"invalid", NewNormalizedJSONValue(`{a:1}`), true},
+		{"null", NewNormalizedJSONNull(), false},
+		{"unknown", NewNormalizedJSONUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &xattr.ValidateAttributeResponse{}
+			tt.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("traits")}, resp)
+			if resp.Diagnostics.HasError() != tt.invalid {
+				t.Errorf("expected invalid=%v, got diagnostics %v", tt.invalid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestNewNormalizedJSONFromObject(t *testing.T) {
+	v, err := NewNormalizedJSONFromObject(map[string]interface{}{"b": 1, "a": "x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.ValueString() != `{"a":"x","b":1}` {
+		t.Errorf("unexpected value %q", v.ValueString())
+	}
+}
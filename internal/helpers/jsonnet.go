@@ -0,0 +1,113 @@
+package helpers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// jsonnetFilename is the file name used in Jsonnet error messages.
+const jsonnetFilename = "snippet.jsonnet"
+
+// JsonnetSource returns the Jsonnet code of a value that Ory accepts as
+// inline Jsonnet or as a base64:// URL. ok is false for references to remote
+// or local files (http://, https://, file://), which cannot be checked.
+func JsonnetSource(value string) (source string, ok bool, err error) {
+	switch {
+	case strings.HasPrefix(value, "base64://"):
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, "base64://"))
+		if err != nil {
+			return "", false, fmt.Errorf("invalid base64:// value: %w", err)
+		}
+		return string(decoded), true, nil
+	case strings.HasPrefix(value, "http://"), strings.HasPrefix(value, "https://"), strings.HasPrefix(value, "file://"):
+		return "", false, nil
+	}
+	return value, true, nil
+}
+
+// CompileJsonnet parses and statically checks a Jsonnet snippet, reporting
+// syntax errors and undefined variables without evaluating it.
+func CompileJsonnet(snippet string) error {
+	_, err := jsonnet.SnippetToAST(jsonnetFilename, snippet)
+	return err
+}
+
+// EvaluateJsonnet evaluates a Jsonnet snippet and returns the resulting JSON
+// document. Each top-level key of the vars JSON object is available as
+// std.extVar(key). If the snippet is a function, like Ory webhook bodies
+// (function(ctx) ...), its parameters are bound to the vars of the same
+// name. Imports are not supported, as Ory does not resolve them either.
+func EvaluateJsonnet(snippet, vars string) (string, error) {
+	node, err := jsonnet.SnippetToAST(jsonnetFilename, snippet)
+	if err != nil {
+		return "", err
+	}
+
+	values := map[string]json.RawMessage{}
+	if strings.TrimSpace(vars) != "" {
+		if err := json.Unmarshal([]byte(vars), &values); err != nil {
+			return "", fmt.Errorf("vars must be a JSON object: %w", err)
+		}
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.MemoryImporter{Data: map[string]jsonnet.Contents{}})
+	for key, value := range values {
+		vm.ExtCode(key, string(value))
+	}
+	if fn, ok := node.(*ast.Function); ok {
+		for _, param := range fn.Parameters {
+			if value, ok := values[string(param.Name)]; ok {
+				vm.TLACode(string(param.Name), string(value))
+			}
+		}
+	}
+
+	out, err := vm.Evaluate(node)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeJSON(out)
+}
+
+// JsonnetValidator returns a validator that compiles Jsonnet values at plan
+// time. Inline Jsonnet and base64:// values are checked; URLs are skipped.
+func JsonnetValidator() validator.String {
+	return jsonnetValidator{}
+}
+
+type jsonnetValidator struct{}
+
+func (v jsonnetValidator) Description(ctx context.Context) string {
+	return "value must be valid Jsonnet"
+}
+
+func (v jsonnetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonnetValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	source, ok, err := JsonnetSource(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Jsonnet", err.Error())
+		return
+	}
+	if !ok {
+		return
+	}
+	if err := CompileJsonnet(source); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Jsonnet",
+			fmt.Sprintf("The Jsonnet code does not compile:\n\n%s", err))
+	}
+}
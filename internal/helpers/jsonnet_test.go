@@ -0,0 +1,130 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJsonnetSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		wantSource string
+		wantOK     bool
+		wantErr    bool
+	}{
+		{name: "inline", value: "{ a: 1 }", wantSource: "{ a: 1 }", wantOK: true},
+		{name: "base64", value: "base64://eyBhOiAxIH0=", wantSource: "{ a: 1 }", wantOK: true},
+		{name: "invalid base64", value: "base64://not base64", wantErr: true},
+		{name: "https", value: "https://example.com/mapper.jsonnet"},
+		{name: "file", value: "file:///etc/mapper.jsonnet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, ok, err := JsonnetSource(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if source != tt.wantSource || ok != tt.wantOK {
+				t.Errorf("got (%q, %v), want (%q, %v)", source, ok, tt.wantSource, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCompileJsonnet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		wantErr string
+	}{
+		{name: "webhook body", snippet: "function(ctx) { identity_id: ctx.identity.id }"},
+		{name: "mapper", snippet: "local claims = std.extVar('claims'); { identity: { traits: { email: claims.email } } }"},
+		{name: "syntax error", snippet: "function(ctx) { identity_id: ctx.identity.id", wantErr: "Expected a comma"},
+		{name: "undefined variable", snippet: "{ email: claims.email }", wantErr: "Unknown variable: claims"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompileJsonnet(tt.snippet)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEvaluateJsonnet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		vars    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "function parameters",
+			snippet: "function(ctx) { identity_id: ctx.identity.id, flow: ctx.flow.type }",
+			vars:    `{"ctx": {"identity": {"id": "abc"}, "flow": {"type": "browser"}}}`,
+			want:    `{"flow":"browser","identity_id":"abc"}`,
+		},
+		{
+			name:    "ext vars",
+			snippet: "local claims = std.extVar('claims'); { identity: { traits: { email: claims.email } } }",
+			vars:    `{"claims": {"email": "user@example.com"}}`,
+			want:    `{"identity":{"traits":{"email":"user@example.com"}}}`,
+		},
+		{
+			name:    "no vars",
+			snippet: "{ a: 1 + 1 }",
+			want:    `{"a":2}`,
+		},
+		{
+			name:    "runtime error",
+			snippet: "function(ctx) { email: ctx.identity.traits.email }",
+			vars:    `{"ctx": {"identity": {}}}`,
+			wantErr: "Field does not exist: traits",
+		},
+		{
+			name:    "missing function argument",
+			snippet: "function(ctx) { id: ctx.identity.id }",
+			wantErr: "Missing argument: ctx",
+		},
+		{
+			name:    "imports are not resolved",
+			snippet: "import 'mapper.libsonnet'",
+			wantErr: "mapper.libsonnet",
+		},
+		{
+			name:    "vars must be an object",
+			snippet: "{}",
+			vars:    `["ctx"]`,
+			wantErr: "vars must be a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateJsonnet(tt.snippet, tt.vars)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,634 @@
+package helpers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// OPLNamespace is a namespace (class) declared in an Ory Permission Language document.
+type OPLNamespace struct {
+	Name        string
+	Line        int
+	Relations   []OPLRelation
+	Permissions []string
+}
+
+// OPLRelation is an entry in a namespace's "related" block.
+type OPLRelation struct {
+	Name     string
+	Line     int
+	Subjects []OPLSubjectType
+}
+
+// OPLSubjectType is an allowed subject of a relation: either a namespace
+// (e.g. User) or a subject set (e.g. SubjectSet<Group, "members">).
+type OPLSubjectType struct {
+	Namespace string
+	Relation  string
+	Line      int
+	Column    int
+}
+
+// OPLError is a syntax or reference error found while parsing OPL.
+type OPLError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e OPLError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// ParseOPL parses an Ory Permission Language (OPL) document and returns the
+// namespaces it declares.
+//
+// This is a lightweight parser for the TypeScript subset accepted by Ory Keto.
+// It reports the first syntax error it encounters, and all references to
+// undeclared namespaces and relations. It does not type-check permit bodies
+// beyond their this.related and this.permits references.
+func ParseOPL(src string) ([]OPLNamespace, []OPLError) {
+	tokens, err := tokenizeOPL(src)
+	if err != nil {
+		return nil, []OPLError{*err}
+	}
+
+	p := &oplParser{tokens: tokens}
+	namespaces, err := p.parseDocument()
+	if err != nil {
+		return nil, []OPLError{*err}
+	}
+
+	errs := p.checkReferences(namespaces)
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+	return namespaces, errs
+}
+
+type oplTokenKind int
+
+const (
+	oplIdent oplTokenKind = iota
+	oplString
+	oplNumber
+	oplPunct
+	oplEOF
+)
+
+type oplToken struct {
+	kind   oplTokenKind
+	value  string
+	line   int
+	column int
+}
+
+func (t oplToken) describe() string {
+	switch t.kind {
+	case oplEOF:
+		return "end of input"
+	case oplString:
+		return fmt.Sprintf("string %q", t.value)
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// oplMultiCharPuncts lists multi-character operators, longest first.
+var oplMultiCharPuncts = []string{"===", "!==", "=>", "||", "&&", "==", "!=", "?."}
+
+func tokenizeOPL(src string) ([]oplToken, *OPLError) {
+	var tokens []oplToken
+	line, col := 1, 1
+	i := 0
+
+	advance := func(n int) {
+		for k := 0; k < n && i < len(src); k++ {
+			if src[i] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+			i++
+		}
+	}
+
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			advance(1)
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				advance(1)
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			startLine, startCol := line, col
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, &OPLError{Line: startLine, Column: startCol, Message: "unterminated block comment"}
+			}
+			advance(end + 4)
+		case isOPLIdentStart(c):
+			start, startLine, startCol := i, line, col
+			for i < len(src) && isOPLIdentPart(src[i]) {
+				advance(1)
+			}
+			tokens = append(tokens, oplToken{kind: oplIdent, value: src[start:i], line: startLine, column: startCol})
+		case c >= '0' && c <= '9':
+			start, startLine, startCol := i, line, col
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				advance(1)
+			}
+			tokens = append(tokens, oplToken{kind: oplNumber, value: src[start:i], line: startLine, column: startCol})
+		case c == '"' || c == '\'' || c == '`':
+			startLine, startCol := line, col
+			advance(1)
+			var sb strings.Builder
+			for {
+				if i >= len(src) || src[i] == '\n' {
+					return nil, &OPLError{Line: startLine, Column: startCol, Message: "unterminated string literal"}
+				}
+				if src[i] == c {
+					advance(1)
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					sb.WriteByte(src[i+1])
+					advance(2)
+					continue
+				}
+				sb.WriteByte(src[i])
+				advance(1)
+			}
+			tokens = append(tokens, oplToken{kind: oplString, value: sb.String(), line: startLine, column: startCol})
+		default:
+			value := string(c)
+			for _, op := range oplMultiCharPuncts {
+				if strings.HasPrefix(src[i:], op) {
+					value = op
+					break
+				}
+			}
+			if !strings.Contains("{}()[]<>,;:.|&=!?+-*/%", value[:1]) {
+				return nil, &OPLError{Line: line, Column: col, Message: fmt.Sprintf("unexpected character %q", c)}
+			}
+			tokens = append(tokens, oplToken{kind: oplPunct, value: value, line: line, column: col})
+			advance(len(value))
+		}
+	}
+
+	tokens = append(tokens, oplToken{kind: oplEOF, line: line, column: col})
+	return tokens, nil
+}
+
+func isOPLIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isOPLIdentPart(c byte) bool {
+	return isOPLIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// oplReference is a this.related.X or this.permits.X reference inside a permit body.
+type oplReference struct {
+	namespace string
+	block     string
+	name      string
+	line      int
+	column    int
+}
+
+type oplParser struct {
+	tokens     []oplToken
+	pos        int
+	references []oplReference
+}
+
+func (p *oplParser) peek() oplToken {
+	return p.tokens[p.pos]
+}
+
+func (p *oplParser) next() oplToken {
+	t := p.tokens[p.pos]
+	if t.kind != oplEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *oplParser) is(value string) bool {
+	t := p.peek()
+	return (t.kind == oplPunct || t.kind == oplIdent) && t.value == value
+}
+
+func (p *oplParser) errorf(t oplToken, format string, args ...any) *OPLError {
+	return &OPLError{Line: t.line, Column: t.column, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *oplParser) expect(value string) (oplToken, *OPLError) {
+	t := p.next()
+	if (t.kind != oplPunct && t.kind != oplIdent) || t.value != value {
+		return t, p.errorf(t, "expected %q, got %s", value, t.describe())
+	}
+	return t, nil
+}
+
+func (p *oplParser) expectIdent(what string) (oplToken, *OPLError) {
+	t := p.next()
+	if t.kind != oplIdent {
+		return t, p.errorf(t, "expected %s, got %s", what, t.describe())
+	}
+	return t, nil
+}
+
+// skipSeparators skips optional ";" and "," between members.
+func (p *oplParser) skipSeparators() {
+	for p.is(";") || p.is(",") {
+		p.next()
+	}
+}
+
+func (p *oplParser) parseDocument() ([]OPLNamespace, *OPLError) {
+	var namespaces []OPLNamespace
+	for {
+		p.skipSeparators()
+		t := p.peek()
+		switch {
+		case t.kind == oplEOF:
+			return namespaces, nil
+		case p.is("import"):
+			if err := p.skipImport(); err != nil {
+				return nil, err
+			}
+		case p.is("class"):
+			ns, err := p.parseClass()
+			if err != nil {
+				return nil, err
+			}
+			namespaces = append(namespaces, *ns)
+		default:
+			return nil, p.errorf(t, "expected import or class declaration, got %s", t.describe())
+		}
+	}
+}
+
+// skipImport skips an import statement up to and including its module string.
+func (p *oplParser) skipImport() *OPLError {
+	start := p.next()
+	for {
+		t := p.next()
+		switch {
+		case t.kind == oplEOF:
+			return p.errorf(start, "unterminated import statement")
+		case t.kind == oplString:
+			return nil
+		}
+	}
+}
+
+func (p *oplParser) parseClass() (*OPLNamespace, *OPLError) {
+	p.next() // class
+	name, err := p.expectIdent("namespace name")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("implements"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("Namespace"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	ns := &OPLNamespace{Name: name.value, Line: name.line}
+	for {
+		p.skipSeparators()
+		t := p.peek()
+		switch {
+		case p.is("}"):
+			p.next()
+			return ns, nil
+		case p.is("related"):
+			p.next()
+			if _, err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if err := p.parseRelated(ns); err != nil {
+				return nil, err
+			}
+		case p.is("permits"):
+			p.next()
+			if _, err := p.expect("="); err != nil {
+				return nil, err
+			}
+			if err := p.parsePermits(ns); err != nil {
+				return nil, err
+			}
+		case t.kind == oplEOF:
+			return nil, p.errorf(name, "unterminated class %q: missing closing \"}\"", name.value)
+		default:
+			return nil, p.errorf(t, "expected \"related\" or \"permits\" in class %q, got %s", name.value, t.describe())
+		}
+	}
+}
+
+func (p *oplParser) parseRelated(ns *OPLNamespace) *OPLError {
+	if _, err := p.expect("{"); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for {
+		p.skipSeparators()
+		if p.is("}") {
+			p.next()
+			return nil
+		}
+		name, err := p.expectIdent("relation name")
+		if err != nil {
+			return err
+		}
+		if seen[name.value] {
+			return p.errorf(name, "duplicate relation %q in namespace %q", name.value, ns.Name)
+		}
+		seen[name.value] = true
+		if _, err := p.expect(":"); err != nil {
+			return err
+		}
+		subjects, err := p.parseRelationType()
+		if err != nil {
+			return err
+		}
+		ns.Relations = append(ns.Relations, OPLRelation{Name: name.value, Line: name.line, Subjects: subjects})
+	}
+}
+
+// parseRelationType parses "T[]", "(A | B)[]" or "Array<A | B>".
+func (p *oplParser) parseRelationType() ([]OPLSubjectType, *OPLError) {
+	if p.is("Array") {
+		p.next()
+		if _, err := p.expect("<"); err != nil {
+			return nil, err
+		}
+		subjects, err := p.parseSubjectUnion()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(">"); err != nil {
+			return nil, err
+		}
+		return subjects, nil
+	}
+
+	var subjects []OPLSubjectType
+	if p.is("(") {
+		p.next()
+		union, err := p.parseSubjectUnion()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		subjects = union
+	} else {
+		subject, err := p.parseSubjectType()
+		if err != nil {
+			return nil, err
+		}
+		subjects = []OPLSubjectType{*subject}
+	}
+
+	if _, err := p.expect("["); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return subjects, nil
+}
+
+func (p *oplParser) parseSubjectUnion() ([]OPLSubjectType, *OPLError) {
+	var subjects []OPLSubjectType
+	for {
+		subject, err := p.parseSubjectType()
+		if err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, *subject)
+		if !p.is("|") {
+			return subjects, nil
+		}
+		p.next()
+	}
+}
+
+// parseSubjectType parses "Namespace" or SubjectSet<Namespace, "relation">.
+func (p *oplParser) parseSubjectType() (*OPLSubjectType, *OPLError) {
+	t, err := p.expectIdent("subject type")
+	if err != nil {
+		return nil, err
+	}
+	if t.value != "SubjectSet" {
+		return &OPLSubjectType{Namespace: t.value, Line: t.line, Column: t.column}, nil
+	}
+
+	if _, err := p.expect("<"); err != nil {
+		return nil, err
+	}
+	ns, err := p.expectIdent("subject set namespace")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(","); err != nil {
+		return nil, err
+	}
+	rel := p.next()
+	if rel.kind != oplString {
+		return nil, p.errorf(rel, "expected subject set relation as a string, got %s", rel.describe())
+	}
+	if _, err := p.expect(">"); err != nil {
+		return nil, err
+	}
+	return &OPLSubjectType{Namespace: ns.value, Relation: rel.value, Line: ns.line, Column: ns.column}, nil
+}
+
+func (p *oplParser) parsePermits(ns *OPLNamespace) *OPLError {
+	if _, err := p.expect("{"); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for {
+		p.skipSeparators()
+		if p.is("}") {
+			p.next()
+			return nil
+		}
+		name, err := p.expectIdent("permission name")
+		if err != nil {
+			return err
+		}
+		if seen[name.value] {
+			return p.errorf(name, "duplicate permission %q in namespace %q", name.value, ns.Name)
+		}
+		seen[name.value] = true
+		if _, err := p.expect(":"); err != nil {
+			return err
+		}
+		if err := p.parsePermitBody(ns, name); err != nil {
+			return err
+		}
+		ns.Permissions = append(ns.Permissions, name.value)
+	}
+}
+
+// parsePermitBody consumes a permit function up to the next top-level "," or
+// the closing "}" of the permits block, checking bracket balance and
+// recording this.related / this.permits references.
+func (p *oplParser) parsePermitBody(ns *OPLNamespace, name oplToken) *OPLError {
+	if !p.is("(") {
+		t := p.peek()
+		return p.errorf(t, "expected permit function for %q, got %s", name.value, t.describe())
+	}
+
+	closers := map[string]string{"(": ")", "[": "]", "{": "}"}
+	var stack []oplToken
+	sawArrow := false
+	for {
+		t := p.peek()
+		if t.kind == oplEOF {
+			if len(stack) > 0 {
+				open := stack[len(stack)-1]
+				return p.errorf(open, "unclosed %q", open.value)
+			}
+			return p.errorf(name, "unterminated permit %q", name.value)
+		}
+		if len(stack) == 0 && (p.is(",") || p.is("}")) {
+			if !sawArrow {
+				return p.errorf(name, "permit %q must be an arrow function: (ctx: Context): boolean => ...", name.value)
+			}
+			return nil
+		}
+		p.next()
+
+		if t.kind != oplPunct && t.kind != oplIdent {
+			continue
+		}
+		switch t.value {
+		case "(", "[", "{":
+			stack = append(stack, t)
+		case ")", "]", "}":
+			if len(stack) == 0 || closers[stack[len(stack)-1].value] != t.value {
+				return p.errorf(t, "unexpected %q", t.value)
+			}
+			stack = stack[:len(stack)-1]
+		case "=>":
+			sawArrow = true
+		case "this":
+			p.recordReference(ns)
+		}
+	}
+}
+
+// recordReference records a "this.related.X" or "this.permits.X" reference
+// following a "this" token.
+func (p *oplParser) recordReference(ns *OPLNamespace) {
+	if p.pos+3 >= len(p.tokens) {
+		return
+	}
+	dot1, block, dot2, member := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2], p.tokens[p.pos+3]
+	if dot1.value != "." || dot2.value != "." || member.kind != oplIdent {
+		return
+	}
+	if block.value != "related" && block.value != "permits" {
+		return
+	}
+	p.references = append(p.references, oplReference{
+		namespace: ns.Name,
+		block:     block.value,
+		name:      member.value,
+		line:      member.line,
+		column:    member.column,
+	})
+}
+
+func (p *oplParser) checkReferences(namespaces []OPLNamespace) []OPLError {
+	var errs []OPLError
+
+	byName := map[string]*OPLNamespace{}
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if prev, ok := byName[ns.Name]; ok {
+			errs = append(errs, OPLError{
+				Line:    ns.Line,
+				Column:  1,
+				Message: fmt.Sprintf("namespace %q is already declared on line %d", ns.Name, prev.Line),
+			})
+			continue
+		}
+		byName[ns.Name] = ns
+	}
+
+	hasMember := func(ns *OPLNamespace, name string, includePermits bool) bool {
+		for _, r := range ns.Relations {
+			if r.Name == name {
+				return true
+			}
+		}
+		return includePermits && slices.Contains(ns.Permissions, name)
+	}
+
+	for _, ns := range namespaces {
+		for _, rel := range ns.Relations {
+			for _, s := range rel.Subjects {
+				target, ok := byName[s.Namespace]
+				if !ok {
+					errs = append(errs, OPLError{
+						Line:    s.Line,
+						Column:  s.Column,
+						Message: fmt.Sprintf("unknown namespace %q in relation %s.%s", s.Namespace, ns.Name, rel.Name),
+					})
+					continue
+				}
+				if s.Relation != "" && !hasMember(target, s.Relation, true) {
+					errs = append(errs, OPLError{
+						Line:    s.Line,
+						Column:  s.Column,
+						Message: fmt.Sprintf("namespace %q has no relation or permission %q (in relation %s.%s)", s.Namespace, s.Relation, ns.Name, rel.Name),
+					})
+				}
+			}
+		}
+	}
+
+	for _, ref := range p.references {
+		ns := byName[ref.namespace]
+		if ns == nil {
+			continue
+		}
+		if ref.block == "related" && !hasMember(ns, ref.name, false) {
+			errs = append(errs, OPLError{
+				Line:    ref.line,
+				Column:  ref.column,
+				Message: fmt.Sprintf("unknown relation %q in namespace %q", ref.name, ref.namespace),
+			})
+		}
+		if ref.block == "permits" && !slices.Contains(ns.Permissions, ref.name) {
+			errs = append(errs, OPLError{
+				Line:    ref.line,
+				Column:  ref.column,
+				Message: fmt.Sprintf("unknown permission %q in namespace %q", ref.name, ref.namespace),
+			})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,190 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+const validOPL = `import { Namespace, Context } from "@ory/keto-namespace-types"
+
+class User implements Namespace {}
+
+class Group implements Namespace {
+  related: {
+    members: (User | SubjectSet<Group, "members">)[]
+  }
+}
+
+/* Documents can be nested in folders. */
+class Document implements Namespace {
+  related: {
+    owners: User[]
+    viewers: Array<User | SubjectSet<Group, "members">>
+    parents: Document[]
+  }
+
+  permits = {
+    edit: (ctx: Context): boolean => this.related.owners.includes(ctx.subject),
+    view: (ctx: Context): boolean =>
+      this.permits.edit(ctx) ||
+      this.related.viewers.includes(ctx.subject) ||
+      this.related.parents.traverse((p) => p.permits.view(ctx)),
+  }
+}
+`
+
+func TestParseOPL_Valid(t *testing.T) {
+	namespaces, errs := ParseOPL(validOPL)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	var names []string
+	for _, ns := range namespaces {
+		names = append(names, ns.Name)
+	}
+	if got := strings.Join(names, ","); got != "User,Group,Document" {
+		t.Errorf("expected namespaces User,Group,Document, got %s", got)
+	}
+
+	doc := namespaces[2]
+	if doc.Line != 12 {
+		t.Errorf("expected Document on line 12, got %d", doc.Line)
+	}
+	if len(doc.Relations) != 3 {
+		t.Fatalf("expected 3 relations, got %d", len(doc.Relations))
+	}
+	viewers := doc.Relations[1]
+	if len(viewers.Subjects) != 2 || viewers.Subjects[1].Namespace != "Group" || viewers.Subjects[1].Relation != "members" {
+		t.Errorf("unexpected viewers subjects: %+v", viewers.Subjects)
+	}
+	if got := strings.Join(doc.Permissions, ","); got != "edit,view" {
+		t.Errorf("expected permissions edit,view, got %s", got)
+	}
+}
+
+func TestParseOPL_Empty(t *testing.T) {
+	namespaces, errs := ParseOPL("// nothing here\n")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(namespaces) != 0 {
+		t.Errorf("expected no namespaces, got %d", len(namespaces))
+	}
+}
+
+func TestParseOPL_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		line    int
+		message string
+	}{
+		{
+			name:    "missing implements",
+			src:     "class User {}",
+			line:    1,
+			message: `expected "implements"`,
+		},
+		{
+			name:    "missing array suffix",
+			src:     "class User implements Namespace {}\nclass Doc implements Namespace {\n  related: {\n    owners: User\n  }\n}",
+			line:    5,
+			message: `expected "["`,
+		},
+		{
+			name:    "unclosed class",
+			src:     "class User implements Namespace {\n",
+			line:    1,
+			message: `unterminated class "User"`,
+		},
+		{
+			name:    "unbalanced permit",
+			src:     "class Doc implements Namespace {\n  permits = {\n    view: (ctx: Context): boolean => (true,\n  }\n}",
+			line:    4,
+			message: `unexpected "}"`,
+		},
+		{
+			name:    "permit is not a function",
+			src:     "class Doc implements Namespace {\n  permits = {\n    view: true,\n  }\n}",
+			line:    3,
+			message: "expected permit function",
+		},
+		{
+			name:    "unterminated string",
+			src:     "class Doc implements Namespace {\n  related: {\n    m: SubjectSet<Doc, \"m>[]\n  }\n}",
+			line:    3,
+			message: "unterminated string literal",
+		},
+		{
+			name:    "stray statement",
+			src:     "const x = 1",
+			line:    1,
+			message: "expected import or class declaration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ParseOPL(tt.src)
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+			}
+			if errs[0].Line != tt.line {
+				t.Errorf("expected error on line %d, got %d (%s)", tt.line, errs[0].Line, errs[0].Message)
+			}
+			if !strings.Contains(errs[0].Message, tt.message) {
+				t.Errorf("expected message containing %q, got %q", tt.message, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestParseOPL_UnknownReferences(t *testing.T) {
+	src := `class User implements Namespace {}
+
+class Document implements Namespace {
+  related: {
+    owners: Usr[]
+    viewers: SubjectSet<Group, "members">[]
+    editors: SubjectSet<User, "friends">[]
+  }
+
+  permits = {
+    view: (ctx: Context): boolean =>
+      this.related.owner.includes(ctx.subject) ||
+      this.permits.edit(ctx),
+  }
+}
+`
+	_, errs := ParseOPL(src)
+
+	expected := []struct {
+		line    int
+		message string
+	}{
+		{5, `unknown namespace "Usr"`},
+		{6, `unknown namespace "Group"`},
+		{7, `namespace "User" has no relation or permission "friends"`},
+		{12, `unknown relation "owner"`},
+		{13, `unknown permission "edit"`},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, want := range expected {
+		if errs[i].Line != want.line || !strings.Contains(errs[i].Message, want.message) {
+			t.Errorf("error %d: expected line %d %q, got %s", i, want.line, want.message, errs[i].Error())
+		}
+	}
+}
+
+func TestParseOPL_DuplicateNamespace(t *testing.T) {
+	_, errs := ParseOPL("class User implements Namespace {}\nclass User implements Namespace {}\n")
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Line != 2 || !strings.Contains(errs[0].Message, "already declared on line 1") {
+		t.Errorf("unexpected error: %s", errs[0].Error())
+	}
+}
@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// SAMLMetadata is the part of SAML 2.0 identity provider metadata that is
+// needed to configure a SAML connection.
+type SAMLMetadata struct {
+	EntityID string
+	// SSOURLs are the locations of the SingleSignOnService endpoints.
+	SSOURLs []string
+	// Certificates are the X.509 signing certificates of the IdP.
+	Certificates []*x509.Certificate
+}
+
+type samlEntityDescriptor struct {
+	XMLName          xml.Name               `xml:"EntityDescriptor"`
+	EntityID         string                 `xml:"entityID,attr"`
+	IDPSSODescriptor []samlIDPSSODescriptor `xml:"IDPSSODescriptor"`
+}
+
+type samlIDPSSODescriptor struct {
+	KeyDescriptors       []samlKeyDescriptor `xml:"KeyDescriptor"`
+	SingleSignOnServices []samlEndpoint      `xml:"SingleSignOnService"`
+}
+
+type samlKeyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+type samlEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+// ParseSAMLMetadata parses SAML 2.0 identity provider metadata. It requires
+// a single EntityDescriptor with an entityID, an IDPSSODescriptor with at
+// least one SingleSignOnService, and valid signing certificates.
+func ParseSAMLMetadata(metadata string) (*SAMLMetadata, error) {
+	var descriptor samlEntityDescriptor
+	if err := xml.Unmarshal([]byte(metadata), &descriptor); err != nil {
+		if strings.Contains(err.Error(), "expected element type <EntityDescriptor>") {
+			return nil, fmt.Errorf("metadata must have a single EntityDescriptor root element: %w", err)
+		}
+		return nil, fmt.Errorf("metadata is not valid XML: %w", err)
+	}
+	if descriptor.EntityID == "" {
+		return nil, fmt.Errorf("EntityDescriptor has no entityID")
+	}
+	if len(descriptor.IDPSSODescriptor) == 0 {
+		return nil, fmt.Errorf("metadata of %s has no IDPSSODescriptor; is it service provider metadata?", descriptor.EntityID)
+	}
+
+	result := &SAMLMetadata{EntityID: descriptor.EntityID}
+	for _, idp := range descriptor.IDPSSODescriptor {
+		for _, endpoint := range idp.SingleSignOnServices {
+			if endpoint.Location != "" {
+				result.SSOURLs = append(result.SSOURLs, endpoint.Location)
+			}
+		}
+		for _, key := range idp.KeyDescriptors {
+			// Keys without a use are used for signing and encryption.
+			if key.Use != "" && key.Use != "signing" {
+				continue
+			}
+			for _, encoded := range key.Certificates {
+				cert, err := parseSAMLCertificate(encoded)
+				if err != nil {
+					return nil, fmt.Errorf("invalid signing certificate: %w", err)
+				}
+				result.Certificates = append(result.Certificates, cert)
+			}
+		}
+	}
+	if len(result.SSOURLs) == 0 {
+		return nil, fmt.Errorf("IDPSSODescriptor has no SingleSignOnService endpoint")
+	}
+	if len(result.Certificates) == 0 {
+		return nil, fmt.Errorf("IDPSSODescriptor has no signing certificate")
+	}
+	return result, nil
+}
+
+func parseSAMLCertificate(encoded string) (*x509.Certificate, error) {
+	// Certificates are often line-wrapped inside the XML element.
+	encoded = strings.Join(strings.Fields(encoded), "")
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("not base64: %w", err)
+	}
+	return x509.ParseCertificate(der)
+}
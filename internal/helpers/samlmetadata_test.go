@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCertificate(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func testIDPMetadata(entityID, keyDescriptor, ssoService string) string {
+	return `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="` + entityID + `">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    ` + keyDescriptor + `
+    ` + ssoService + `
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+}
+
+func TestParseSAMLMetadata(t *testing.T) {
+	cert := testCertificate(t)
+	signingKey := `<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + cert + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>`
+	ssoService := `<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>`
+
+	t.Run("valid", func(t *testing.T) {
+		metadata, err := ParseSAMLMetadata(testIDPMetadata("https://idp.example.com", signingKey, ssoService))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if metadata.EntityID != "https://idp.example.com" {
+			t.Errorf("unexpected entity ID %q", metadata.EntityID)
+		}
+		if len(metadata.SSOURLs) != 1 || metadata.SSOURLs[0] != "https://idp.example.com/sso" {
+			t.Errorf("unexpected SSO URLs %v", metadata.SSOURLs)
+		}
+		if len(metadata.Certificates) != 1 || metadata.Certificates[0].Subject.CommonName != "idp.example.com" {
+			t.Errorf("unexpected certificates %v", metadata.Certificates)
+		}
+	})
+
+	t.Run("line-wrapped certificate", func(t *testing.T) {
+		wrapped := strings.Replace(signingKey, cert, cert[:40]+"\n      "+cert[40:], 1)
+		if _, err := ParseSAMLMetadata(testIDPMetadata("https://idp.example.com", wrapped, ssoService)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		metadata string
+		wantErr  string
+	}{
+		{name: "not XML", metadata: "{}", wantErr: "not valid XML"},
+		{name: "wrong root", metadata: `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata"/>`, wantErr: "single EntityDescriptor"},
+		{name: "no entity ID", metadata: testIDPMetadata("", signingKey, ssoService), wantErr: "no entityID"},
+		{
+			name:     "service provider metadata",
+			metadata: `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com"><SPSSODescriptor/></EntityDescriptor>`,
+			wantErr:  "no IDPSSODescriptor",
+		},
+		{name: "no SSO service", metadata: testIDPMetadata("https://idp.example.com", signingKey, ""), wantErr: "no SingleSignOnService"},
+		{name: "no certificate", metadata: testIDPMetadata("https://idp.example.com", "", ssoService), wantErr: "no signing certificate"},
+		{
+			name:     "invalid certificate",
+			metadata: testIDPMetadata("https://idp.example.com", strings.Replace(signingKey, cert, "bm90IGEgY2VydA==", 1), ssoService),
+			wantErr:  "invalid signing certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSAMLMetadata(tt.metadata)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
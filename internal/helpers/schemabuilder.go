@@ -0,0 +1,307 @@
+package helpers
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Identity schema presets for common login setups.
+const (
+	// SchemaPresetEmailPassword signs in with an email address and a
+	// password, one-time code, security key, passkey or TOTP.
+	SchemaPresetEmailPassword = "email_password"
+	// SchemaPresetUsername signs in with a username and a password,
+	// security key, passkey or TOTP. It has no recovery address.
+	SchemaPresetUsername = "username"
+	// SchemaPresetPhoneCode signs in with a one-time code sent by SMS.
+	SchemaPresetPhoneCode = "phone_code"
+	// SchemaPresetPasskey signs in with a passkey, with a one-time code sent
+	// by email as fallback.
+	SchemaPresetPasskey = "passkey"
+)
+
+// SchemaPresets lists the supported identity schema presets.
+var SchemaPresets = []string{
+	SchemaPresetEmailPassword,
+	SchemaPresetUsername,
+	SchemaPresetPhoneCode,
+	SchemaPresetPasskey,
+}
+
+// SchemaTraitTypes lists the trait types supported by the schema builder.
+var SchemaTraitTypes = []string{"string", "number", "integer", "boolean"}
+
+// SchemaTraitCredentials lists the credential methods a trait can be
+// annotated for. password, code and webauthn use the trait as login
+// identifier, passkey as display name and totp as account name.
+var SchemaTraitCredentials = []string{"password", "code", "webauthn", "passkey", "totp"}
+
+// SchemaTrait describes a trait of a generated identity schema.
+type SchemaTrait struct {
+	Name     string
+	Type     string
+	Title    string
+	Format   string
+	Required bool
+
+	MinLength *int64
+	MaxLength *int64
+	Pattern   string
+
+	// Credentials lists the credential methods the trait is used for. See
+	// SchemaTraitCredentials.
+	Credentials []string
+	// Verification and Recovery send verification and recovery messages to
+	// the trait. Like the code credential, they need format "email" or "tel".
+	Verification bool
+	Recovery     bool
+}
+
+// PresetTraits returns the traits of an identity schema preset.
+func PresetTraits(preset string) ([]SchemaTrait, error) {
+	maxEmailLength := int64(320)
+	switch preset {
+	case SchemaPresetEmailPassword:
+		return []SchemaTrait{{
+			Name:         "email",
+			Type:         "string",
+			Title:        "E-Mail",
+			Format:       "email",
+			Required:     true,
+			MaxLength:    &maxEmailLength,
+			Credentials:  []string{"password", "code", "webauthn", "passkey", "totp"},
+			Verification: true,
+			Recovery:     true,
+		}}, nil
+	case SchemaPresetUsername:
+		minLength, maxLength := int64(3), int64(100)
+		return []SchemaTrait{{
+			Name:        "username",
+			Type:        "string",
+			Title:       "Username",
+			Required:    true,
+			MinLength:   &minLength,
+			MaxLength:   &maxLength,
+			Credentials: []string{"password", "webauthn", "passkey", "totp"},
+		}}, nil
+	case SchemaPresetPhoneCode:
+		return []SchemaTrait{{
+			Name:         "phone",
+			Type:         "string",
+			Title:        "Phone number",
+			Format:       "tel",
+			Required:     true,
+			Credentials:  []string{"code"},
+			Verification: true,
+		}}, nil
+	case SchemaPresetPasskey:
+		return []SchemaTrait{{
+			Name:         "email",
+			Type:         "string",
+			Title:        "E-Mail",
+			Format:       "email",
+			Required:     true,
+			MaxLength:    &maxEmailLength,
+			Credentials:  []string{"passkey", "code"},
+			Verification: true,
+			Recovery:     true,
+		}}, nil
+	}
+	return nil, fmt.Errorf("unknown identity schema preset %q, expected one of %v", preset, SchemaPresets)
+}
+
+// BuildIdentitySchema generates an identity JSON Schema from traits. The
+// ory.sh/kratos credentials, verification and recovery annotations are
+// derived from each trait's Credentials, Verification, Recovery and Format.
+func BuildIdentitySchema(title string, traits []SchemaTrait) (map[string]interface{}, error) {
+	if len(traits) == 0 {
+		return nil, fmt.Errorf("at least one trait is required")
+	}
+
+	properties := make(map[string]interface{}, len(traits))
+	required := []interface{}{}
+	identifiers := 0
+	for _, trait := range traits {
+		if trait.Name == "" {
+			return nil, fmt.Errorf("trait name must not be empty")
+		}
+		if _, ok := properties[trait.Name]; ok {
+			return nil, fmt.Errorf("duplicate trait %q", trait.Name)
+		}
+
+		property, err := buildTraitSchema(trait)
+		if err != nil {
+			return nil, fmt.Errorf("trait %q: %w", trait.Name, err)
+		}
+		properties[trait.Name] = property
+		if trait.Required {
+			required = append(required, trait.Name)
+		}
+		if hasIdentifier(property) {
+			identifiers++
+		}
+	}
+	if identifiers == 0 {
+		return nil, fmt.Errorf("at least one trait must be a login identifier (credentials password, code or webauthn)")
+	}
+
+	traitsSchema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		traitsSchema["required"] = required
+	}
+
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title":   title,
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"traits": traitsSchema,
+		},
+	}, nil
+}
+
+func buildTraitSchema(trait SchemaTrait) (map[string]interface{}, error) {
+	traitType := trait.Type
+	if traitType == "" {
+		traitType = "string"
+	}
+	if !slices.Contains(SchemaTraitTypes, traitType) {
+		return nil, fmt.Errorf("unsupported type %q, expected one of %v", traitType, SchemaTraitTypes)
+	}
+
+	property := map[string]interface{}{"type": traitType}
+	if trait.Title != "" {
+		property["title"] = trait.Title
+	}
+	if trait.Format != "" {
+		property["format"] = trait.Format
+	}
+	if trait.Pattern != "" {
+		property["pattern"] = trait.Pattern
+	}
+	if trait.MinLength != nil {
+		property["minLength"] = *trait.MinLength
+	}
+	if trait.MaxLength != nil {
+		property["maxLength"] = *trait.MaxLength
+	}
+
+	if traitType != "string" && (len(trait.Credentials) > 0 || trait.Verification || trait.Recovery) {
+		return nil, fmt.Errorf("credentials, verification and recovery require type \"string\"")
+	}
+
+	// Codes, verification and recovery messages are sent by email or SMS,
+	// depending on the format of the trait.
+	via := map[string]string{"email": "email", "tel": "sms"}[trait.Format]
+	needsVia := func(what string) error {
+		if via == "" {
+			return fmt.Errorf("%s requires format \"email\" or \"tel\"", what)
+		}
+		return nil
+	}
+
+	extension := map[string]interface{}{}
+	credentials := map[string]interface{}{}
+	for _, method := range trait.Credentials {
+		switch method {
+		case "password", "webauthn":
+			credentials[method] = map[string]interface{}{"identifier": true}
+		case "code":
+			if err := needsVia("the code credential"); err != nil {
+				return nil, err
+			}
+			credentials[method] = map[string]interface{}{"identifier": true, "via": via}
+		case "passkey":
+			credentials[method] = map[string]interface{}{"display_name": true}
+		case "totp":
+			credentials[method] = map[string]interface{}{"account_name": true}
+		default:
+			return nil, fmt.Errorf("unsupported credential %q, expected one of %v", method, SchemaTraitCredentials)
+		}
+	}
+	if len(credentials) > 0 {
+		extension["credentials"] = credentials
+	}
+	if trait.Verification {
+		if err := needsVia("verification"); err != nil {
+			return nil, err
+		}
+		extension["verification"] = map[string]interface{}{"via": via}
+	}
+	if trait.Recovery {
+		if err := needsVia("recovery"); err != nil {
+			return nil, err
+		}
+		extension["recovery"] = map[string]interface{}{"via": via}
+	}
+	if len(extension) > 0 {
+		property["ory.sh/kratos"] = extension
+	}
+
+	return property, nil
+}
+
+// DescribeIdentitySchemaTraits is the inverse of BuildIdentitySchema: it
+// returns the traits of an identity schema with their ory.sh/kratos
+// annotations. Nested object traits are flattened, with the dotted path as
+// name, e.g. "name.first". Traits are sorted by name.
+func DescribeIdentitySchemaTraits(identitySchema map[string]interface{}) []SchemaTrait {
+	var traits []SchemaTrait
+	describeTraits("", traitsSchema(identitySchema), &traits)
+	return traits
+}
+
+func describeTraits(prefix string, node map[string]interface{}, traits *[]SchemaTrait) {
+	properties, _ := node["properties"].(map[string]interface{})
+	required := stringSet(node["required"])
+
+	for _, name := range sortedKeys(properties) {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, nested := property["properties"]; nested {
+			describeTraits(prefix+name+".", property, traits)
+			continue
+		}
+
+		trait := SchemaTrait{
+			Name:     prefix + name,
+			Type:     schemaType(property),
+			Required: required[name],
+		}
+		trait.Title, _ = property["title"].(string)
+		trait.Format, _ = property["format"].(string)
+		trait.Pattern, _ = property["pattern"].(string)
+		if v, ok := property["minLength"].(float64); ok {
+			minLength := int64(v)
+			trait.MinLength = &minLength
+		}
+		if v, ok := property["maxLength"].(float64); ok {
+			maxLength := int64(v)
+			trait.MaxLength = &maxLength
+		}
+
+		extension, _ := property["ory.sh/kratos"].(map[string]interface{})
+		credentials, _ := extension["credentials"].(map[string]interface{})
+		trait.Credentials = sortedKeys(credentials)
+		_, trait.Verification = extension["verification"].(map[string]interface{})
+		_, trait.Recovery = extension["recovery"].(map[string]interface{})
+
+		*traits = append(*traits, trait)
+	}
+}
+
+// IsIdentifier reports whether the trait is a login identifier.
+func (t SchemaTrait) IsIdentifier() bool {
+	for _, method := range t.Credentials {
+		if method == "password" || method == "code" || method == "webauthn" {
+			return true
+		}
+	}
+	return false
+}
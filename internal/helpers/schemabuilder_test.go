@@ -0,0 +1,161 @@
+package helpers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPresetTraits(t *testing.T) {
+	validTraits := map[string]interface{}{
+		SchemaPresetEmailPassword: map[string]interface{}{"email": "user@example.com"},
+		SchemaPresetUsername:      map[string]interface{}{"username": "user"},
+		SchemaPresetPhoneCode:     map[string]interface{}{"phone": "+49123456789"},
+		SchemaPresetPasskey:       map[string]interface{}{"email": "user@example.com"},
+	}
+
+	for _, preset := range SchemaPresets {
+		t.Run(preset, func(t *testing.T) {
+			traits, err := PresetTraits(preset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			identitySchema, err := BuildIdentitySchema("Test", traits)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Round-trip through JSON, as the schema is sent to the API.
+			raw, err := json.Marshal(identitySchema)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			traitErrors, err := ValidateTraits(parseJSON(t, string(raw)), validTraits[preset])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(traitErrors) > 0 {
+				t.Errorf("expected valid traits, got %v", traitErrors)
+			}
+		})
+	}
+}
+
+func TestPresetTraits_Unknown(t *testing.T) {
+	if _, err := PresetTraits("nope"); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+}
+
+func TestBuildIdentitySchema(t *testing.T) {
+	maxLength := int64(320)
+	identitySchema, err := BuildIdentitySchema("Customer", []SchemaTrait{
+		{
+			Name:         "email",
+			Title:        "E-Mail",
+			Format:       "email",
+			Required:     true,
+			MaxLength:    &maxLength,
+			Credentials:  []string{"password", "code"},
+			Verification: true,
+			Recovery:     true,
+		},
+		{Name: "phone", Format: "tel", Credentials: []string{"code"}},
+		{Name: "newsletter", Type: "boolean"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := json.Marshal(identitySchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"$schema":"http://json-schema.org/draft-07/schema#","properties":{"traits":{"additionalProperties":false,"properties":{` +
+		`"email":{"format":"email","maxLength":320,"ory.sh/kratos":{"credentials":{"code":{"identifier":true,"via":"email"},"password":{"identifier":true}},"recovery":{"via":"email"},"verification":{"via":"email"}},"title":"E-Mail","type":"string"},` +
+		`"newsletter":{"type":"boolean"},` +
+		`"phone":{"format":"tel","ory.sh/kratos":{"credentials":{"code":{"identifier":true,"via":"sms"}}},"type":"string"}},` +
+		`"required":["email"],"type":"object"}},"title":"Customer","type":"object"}`
+	if string(raw) != expected {
+		t.Errorf("unexpected schema:\n got: %s\nwant: %s", raw, expected)
+	}
+}
+
+func TestBuildIdentitySchema_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		traits []SchemaTrait
+		errMsg string
+	}{
+		{"no traits", nil, "at least one trait"},
+		{"no identifier", []SchemaTrait{{Name: "email", Format: "email", Credentials: []string{"totp"}}}, "login identifier"},
+		{"duplicate", []SchemaTrait{{Name: "email", Credentials: []string{"password"}}, {Name: "email"}}, "duplicate trait"},
+		{"empty name", []SchemaTrait{{Credentials: []string{"password"}}}, "name must not be empty"},
+		{"unknown type", []SchemaTrait{{Name: "email", Type: "date", Credentials: []string{"password"}}}, "unsupported type"},
+		{"unknown credential", []SchemaTrait{{Name: "email", Credentials: []string{"oidc"}}}, "unsupported credential"},
+		{"code without format", []SchemaTrait{{Name: "email", Credentials: []string{"code"}}}, "code credential requires format"},
+		{"verification without format", []SchemaTrait{{Name: "email", Credentials: []string{"password"}, Verification: true}}, "verification requires format"},
+		{"identifier on number", []SchemaTrait{{Name: "id", Type: "integer", Credentials: []string{"password"}}}, "require type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildIdentitySchema("Test", tt.traits)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %q", tt.errMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestDescribeIdentitySchemaTraits(t *testing.T) {
+	traits := DescribeIdentitySchemaTraits(parseJSON(t, testIdentitySchema))
+
+	var names []string
+	for _, trait := range traits {
+		names = append(names, trait.Name)
+	}
+	if strings.Join(names, ",") != "age,email,name.first,name.last" {
+		t.Fatalf("unexpected traits %v", names)
+	}
+
+	email := traits[1]
+	if email.Type != "string" || email.Format != "email" || !email.Required || !email.IsIdentifier() || len(email.Credentials) != 1 || email.Credentials[0] != "password" {
+		t.Errorf("unexpected email trait %+v", email)
+	}
+	first := traits[2]
+	if !first.Required || first.MinLength == nil || *first.MinLength != 1 || first.IsIdentifier() {
+		t.Errorf("unexpected name.first trait %+v", first)
+	}
+	if traits[0].Required || traits[0].Type != "integer" {
+		t.Errorf("unexpected age trait %+v", traits[0])
+	}
+}
+
+func TestDescribeIdentitySchemaTraits_RoundTrip(t *testing.T) {
+	traits, err := PresetTraits(SchemaPresetEmailPassword)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	identitySchema, err := BuildIdentitySchema("Test", traits)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, err := json.Marshal(identitySchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	described := DescribeIdentitySchemaTraits(parseJSON(t, string(raw)))
+	if len(described) != 1 {
+		t.Fatalf("expected 1 trait, got %+v", described)
+	}
+	email := described[0]
+	if email.Name != "email" || !email.Verification || !email.Recovery || *email.MaxLength != 320 ||
+		strings.Join(email.Credentials, ",") != "code,passkey,password,totp,webauthn" {
+		t.Errorf("unexpected email trait %+v", email)
+	}
+}
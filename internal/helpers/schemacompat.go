@@ -0,0 +1,315 @@
+package helpers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Kinds of identity schema changes, from harmless to unsafe for existing
+// identities.
+const (
+	// SchemaChangeAdditive changes accept every identity the old schema
+	// accepted, e.g. a new optional trait.
+	SchemaChangeAdditive = "additive"
+	// SchemaChangeNarrowing changes may reject some existing identities,
+	// e.g. a stricter format or a lower maximum length.
+	SchemaChangeNarrowing = "narrowing"
+	// SchemaChangeBreaking changes reject or lock out existing identities,
+	// e.g. a new required trait or a removed login identifier.
+	SchemaChangeBreaking = "breaking"
+)
+
+// SchemaChange is a difference between two identity schemas.
+type SchemaChange struct {
+	Kind string
+	// Path is the dotted path of the trait, e.g. "traits.name.first".
+	Path    string
+	Message string
+}
+
+func (c SchemaChange) Error() string {
+	return c.Path + ": " + c.Message
+}
+
+var schemaChangeRank = map[string]int{
+	SchemaChangeBreaking:  0,
+	SchemaChangeNarrowing: 1,
+	SchemaChangeAdditive:  2,
+}
+
+// CompareIdentitySchemas classifies the differences between the traits of
+// two identity schemas. Changes are sorted by kind, breaking first, and then
+// by path. Only local schema content is compared; $ref targets are not
+// resolved.
+func CompareIdentitySchemas(oldSchema, newSchema map[string]interface{}) []SchemaChange {
+	var changes []SchemaChange
+	compareSchemaNode("traits", traitsSchema(oldSchema), traitsSchema(newSchema), &changes)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return schemaChangeRank[changes[i].Kind] < schemaChangeRank[changes[j].Kind]
+		}
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func traitsSchema(identitySchema map[string]interface{}) map[string]interface{} {
+	properties, _ := identitySchema["properties"].(map[string]interface{})
+	traits, _ := properties["traits"].(map[string]interface{})
+	if traits == nil {
+		return map[string]interface{}{}
+	}
+	return traits
+}
+
+func compareSchemaNode(p string, oldNode, newNode map[string]interface{}, changes *[]SchemaChange) {
+	add := func(kind, format string, args ...interface{}) {
+		*changes = append(*changes, SchemaChange{Kind: kind, Path: p, Message: fmt.Sprintf(format, args...)})
+	}
+
+	oldType, newType := schemaType(oldNode), schemaType(newNode)
+	if oldType != "" && newType != "" && oldType != newType {
+		add(SchemaChangeBreaking, "type changed from %s to %s", oldType, newType)
+		return
+	}
+
+	compareKratosExtension(p, oldNode["ory.sh/kratos"], newNode["ory.sh/kratos"], changes)
+
+	for _, key := range []string{"format", "pattern", "const"} {
+		oldValue, hadOld := oldNode[key]
+		newValue, hasNew := newNode[key]
+		switch {
+		case hasNew && !hadOld:
+			add(SchemaChangeNarrowing, "%s %v added", key, newValue)
+		case hasNew && !reflect.DeepEqual(oldValue, newValue):
+			add(SchemaChangeNarrowing, "%s changed from %v to %v", key, oldValue, newValue)
+		case hadOld && !hasNew:
+			add(SchemaChangeAdditive, "%s %v removed", key, oldValue)
+		}
+	}
+
+	for _, key := range []string{"minLength", "minimum", "exclusiveMinimum", "minItems"} {
+		compareBound(key, oldNode, newNode, func(oldBound, newBound float64) bool { return newBound > oldBound }, add)
+	}
+	for _, key := range []string{"maxLength", "maximum", "exclusiveMaximum", "maxItems"} {
+		compareBound(key, oldNode, newNode, func(oldBound, newBound float64) bool { return newBound < oldBound }, add)
+	}
+
+	if newEnum, ok := newNode["enum"].([]interface{}); ok {
+		oldEnum, hadEnum := oldNode["enum"].([]interface{})
+		switch {
+		case !hadEnum:
+			add(SchemaChangeNarrowing, "enum %v added", newEnum)
+		case len(missingValues(oldEnum, newEnum)) > 0:
+			add(SchemaChangeNarrowing, "enum values %v removed", missingValues(oldEnum, newEnum))
+		case len(missingValues(newEnum, oldEnum)) > 0:
+			add(SchemaChangeAdditive, "enum values %v added", missingValues(newEnum, oldEnum))
+		}
+	}
+
+	if oldItems, ok := oldNode["items"].(map[string]interface{}); ok {
+		if newItems, ok := newNode["items"].(map[string]interface{}); ok {
+			compareSchemaNode(p+"[]", oldItems, newItems, changes)
+		}
+	}
+
+	compareProperties(p, oldNode, newNode, changes)
+}
+
+func compareProperties(p string, oldNode, newNode map[string]interface{}, changes *[]SchemaChange) {
+	oldProps, _ := oldNode["properties"].(map[string]interface{})
+	newProps, _ := newNode["properties"].(map[string]interface{})
+	oldRequired, newRequired := stringSet(oldNode["required"]), stringSet(newNode["required"])
+	closed := newNode["additionalProperties"] == false
+
+	if closed && oldNode["additionalProperties"] != false {
+		*changes = append(*changes, SchemaChange{Kind: SchemaChangeNarrowing, Path: p, Message: "additional properties are no longer allowed"})
+	}
+
+	for _, name := range sortedKeys(newProps) {
+		childPath := p + "." + name
+		newChild, _ := newProps[name].(map[string]interface{})
+		oldChild, existed := oldProps[name].(map[string]interface{})
+		switch {
+		case !existed && newRequired[name]:
+			*changes = append(*changes, SchemaChange{Kind: SchemaChangeBreaking, Path: childPath, Message: "new required trait; existing identities do not have it"})
+		case !existed:
+			*changes = append(*changes, SchemaChange{Kind: SchemaChangeAdditive, Path: childPath, Message: "new optional trait"})
+		default:
+			if newRequired[name] && !oldRequired[name] {
+				*changes = append(*changes, SchemaChange{Kind: SchemaChangeBreaking, Path: childPath, Message: "trait is now required; existing identities may not have it"})
+			} else if oldRequired[name] && !newRequired[name] {
+				*changes = append(*changes, SchemaChange{Kind: SchemaChangeAdditive, Path: childPath, Message: "trait is no longer required"})
+			}
+			if newChild != nil {
+				compareSchemaNode(childPath, oldChild, newChild, changes)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(oldProps) {
+		if _, ok := newProps[name]; ok {
+			continue
+		}
+		childPath := p + "." + name
+		if oldChild, ok := oldProps[name].(map[string]interface{}); ok && hasIdentifier(oldChild) {
+			*changes = append(*changes, SchemaChange{Kind: SchemaChangeBreaking, Path: childPath, Message: "login identifier trait removed; identities cannot sign in with it anymore"})
+		} else if closed {
+			*changes = append(*changes, SchemaChange{Kind: SchemaChangeBreaking, Path: childPath, Message: "trait removed while additional properties are not allowed; identities that have it are rejected"})
+		} else {
+			*changes = append(*changes, SchemaChange{Kind: SchemaChangeNarrowing, Path: childPath, Message: "trait removed; existing values are no longer validated or used"})
+		}
+	}
+}
+
+func compareKratosExtension(p string, oldExt, newExt interface{}, changes *[]SchemaChange) {
+	oldMap, _ := oldExt.(map[string]interface{})
+	newMap, _ := newExt.(map[string]interface{})
+	add := func(kind, format string, args ...interface{}) {
+		*changes = append(*changes, SchemaChange{Kind: kind, Path: p, Message: fmt.Sprintf(format, args...)})
+	}
+
+	oldCreds, _ := oldMap["credentials"].(map[string]interface{})
+	newCreds, _ := newMap["credentials"].(map[string]interface{})
+	methods := map[string]bool{}
+	for m := range oldCreds {
+		methods[m] = true
+	}
+	for m := range newCreds {
+		methods[m] = true
+	}
+	for _, method := range sortedKeys(methods) {
+		oldConfig, _ := oldCreds[method].(map[string]interface{})
+		newConfig, _ := newCreds[method].(map[string]interface{})
+		oldIdentifier, newIdentifier := oldConfig["identifier"] == true, newConfig["identifier"] == true
+		switch {
+		case oldIdentifier && !newIdentifier:
+			add(SchemaChangeBreaking, "no longer a %s identifier; identities cannot sign in with it anymore", method)
+		case !oldIdentifier && newIdentifier:
+			add(SchemaChangeNarrowing, "now a %s identifier; existing values must be unique", method)
+		}
+
+		switch {
+		case oldConfig == nil:
+			if !newIdentifier {
+				add(SchemaChangeAdditive, "%s credentials configured", method)
+			}
+		case newConfig == nil:
+			if !oldIdentifier {
+				add(SchemaChangeBreaking, "%s credential configuration removed", method)
+			}
+		case !reflect.DeepEqual(withoutKey(oldConfig, "identifier"), withoutKey(newConfig, "identifier")):
+			add(SchemaChangeBreaking, "%s credential configuration changed from %s to %s", method, describe(oldCreds[method]), describe(newCreds[method]))
+		}
+	}
+
+	for _, flow := range []string{"verification", "recovery"} {
+		oldFlow, newFlow := oldMap[flow], newMap[flow]
+		if !reflect.DeepEqual(oldFlow, newFlow) {
+			add(SchemaChangeNarrowing, "%s configuration changed from %s to %s", flow, describe(oldFlow), describe(newFlow))
+		}
+	}
+}
+
+func compareBound(key string, oldNode, newNode map[string]interface{}, stricter func(oldBound, newBound float64) bool, add func(kind, format string, args ...interface{})) {
+	newBound, hasNew := newNode[key].(float64)
+	oldBound, hadOld := oldNode[key].(float64)
+	switch {
+	case hasNew && !hadOld:
+		add(SchemaChangeNarrowing, "%s %v added", key, newBound)
+	case hasNew && stricter(oldBound, newBound):
+		add(SchemaChangeNarrowing, "%s changed from %v to %v", key, oldBound, newBound)
+	case hasNew && newBound != oldBound, hadOld && !hasNew:
+		add(SchemaChangeAdditive, "%s relaxed", key)
+	}
+}
+
+func schemaType(node map[string]interface{}) string {
+	switch t := node["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		parts := make([]string, len(t))
+		for i, v := range t {
+			parts[i] = fmt.Sprint(v)
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, "|")
+	}
+	return ""
+}
+
+// hasIdentifier reports whether a trait is a login identifier for any
+// credential type.
+func hasIdentifier(node map[string]interface{}) bool {
+	ext, _ := node["ory.sh/kratos"].(map[string]interface{})
+	creds, _ := ext["credentials"].(map[string]interface{})
+	for _, c := range creds {
+		if config, ok := c.(map[string]interface{}); ok && config["identifier"] == true {
+			return true
+		}
+	}
+	return false
+}
+
+func stringSet(v interface{}) map[string]bool {
+	set := map[string]bool{}
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			set[s] = true
+		}
+	}
+	return set
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// missingValues returns the values of from that are not in to.
+func missingValues(from, to []interface{}) []interface{} {
+	var missing []interface{}
+	for _, v := range from {
+		found := false
+		for _, w := range to {
+			if reflect.DeepEqual(v, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+func withoutKey(m map[string]interface{}, key string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k != key {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func describe(v interface{}) string {
+	if v == nil {
+		return "none"
+	}
+	s, err := NewNormalizedJSONFromObject(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return s.ValueString()
+}
@@ -0,0 +1,129 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareIdentitySchemas_Unchanged(t *testing.T) {
+	if changes := CompareIdentitySchemas(parseJSON(t, testIdentitySchema), parseJSON(t, testIdentitySchema)); len(changes) > 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestCompareIdentitySchemas(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		kind    string
+		path    string
+		message string
+	}{
+		{
+			"new optional trait",
+			`{"properties":{"traits":{"properties":{"email":{"type":"string"}}}}}`,
+			`{"properties":{"traits":{"properties":{"email":{"type":"string"},"phone":{"type":"string"}}}}}`,
+			SchemaChangeAdditive, "traits.phone", "new optional trait",
+		},
+		{
+			"new required trait",
+			`{"properties":{"traits":{"properties":{"email":{"type":"string"}}}}}`,
+			`{"properties":{"traits":{"properties":{"email":{"type":"string"},"phone":{"type":"string"}},"required":["phone"]}}}`,
+			SchemaChangeBreaking, "traits.phone", "new required trait",
+		},
+		{
+			"trait now required",
+			`{"properties":{"traits":{"properties":{"name":{"type":"object","properties":{"first":{"type":"string"}}}}}}}`,
+			`{"properties":{"traits":{"properties":{"name":{"type":"object","properties":{"first":{"type":"string"}},"required":["first"]}}}}}`,
+			SchemaChangeBreaking, "traits.name.first", "now required",
+		},
+		{
+			"type changed",
+			`{"properties":{"traits":{"properties":{"age":{"type":"string"}}}}}`,
+			`{"properties":{"traits":{"properties":{"age":{"type":"integer"}}}}}`,
+			SchemaChangeBreaking, "traits.age", "type changed from string to integer",
+		},
+		{
+			"identifier removed",
+			`{"properties":{"traits":{"properties":{"email":{"type":"string","ory.sh/kratos":{"credentials":{"password":{"identifier":true}}}}}}}}`,
+			`{"properties":{"traits":{"properties":{"email":{"type":"string"}}}}}`,
+			SchemaChangeBreaking, "traits.email", "no longer a password identifier",
+		},
+		{
+			"identifier trait removed",
+			`{"properties":{"traits":{"properties":{"email":{"type":"string","ory.sh/kratos":{"credentials":{"password":{"identifier":true}}}}}}}}`,
+			`{"properties":{"traits":{"properties":{}}}}`,
+			SchemaChangeBreaking, "traits.email", "login identifier trait removed",
+		},
+		{
+			"credential config changed",
+			`{"properties":{"traits":{"properties":{"email":{"type":"string","ory.sh/kratos":{"credentials":{"code":{"identifier":true,"via":"email"}}}}}}}}`,
+			`{"properties":{"traits":{"properties":{"email":{"type":"string","ory.sh/kratos":{"credentials":{"code":{"identifier":true,"via":"sms"}}}}}}}}`,
+			SchemaChangeBreaking, "traits.email", `code credential configuration changed from {"identifier":true,"via":"email"} to {"identifier":true,"via":"sms"}`,
+		},
+		{
+			"format added",
+			`{"properties":{"traits":{"properties":{"email":{"type":"string"}}}}}`,
+			`{"properties":{"traits":{"properties":{"email":{"type":"string","format":"email"}}}}}`,
+			SchemaChangeNarrowing, "traits.email", "format email added",
+		},
+		{
+			"max length lowered",
+			`{"properties":{"traits":{"properties":{"name":{"type":"string","maxLength":100}}}}}`,
+			`{"properties":{"traits":{"properties":{"name":{"type":"string","maxLength":50}}}}}`,
+			SchemaChangeNarrowing, "traits.name", "maxLength changed from 100 to 50",
+		},
+		{
+			"min length lowered",
+			`{"properties":{"traits":{"properties":{"name":{"type":"string","minLength":3}}}}}`,
+			`{"properties":{"traits":{"properties":{"name":{"type":"string","minLength":1}}}}}`,
+			SchemaChangeAdditive, "traits.name", "minLength relaxed",
+		},
+		{
+			"enum value removed",
+			`{"properties":{"traits":{"properties":{"plan":{"type":"string","enum":["free","pro"]}}}}}`,
+			`{"properties":{"traits":{"properties":{"plan":{"type":"string","enum":["pro"]}}}}}`,
+			SchemaChangeNarrowing, "traits.plan", "enum values [free] removed",
+		},
+		{
+			"trait removed from closed object",
+			`{"properties":{"traits":{"properties":{"nick":{"type":"string"}},"additionalProperties":false}}}`,
+			`{"properties":{"traits":{"properties":{},"additionalProperties":false}}}`,
+			SchemaChangeBreaking, "traits.nick", "additional properties are not allowed",
+		},
+		{
+			"verification changed",
+			`{"properties":{"traits":{"properties":{"email":{"type":"string","ory.sh/kratos":{"verification":{"via":"email"}}}}}}}`,
+			`{"properties":{"traits":{"properties":{"email":{"type":"string"}}}}}`,
+			SchemaChangeNarrowing, "traits.email", "verification configuration changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := CompareIdentitySchemas(parseJSON(t, tt.from), parseJSON(t, tt.to))
+			if len(changes) != 1 {
+				t.Fatalf("expected 1 change, got %v", changes)
+			}
+			c := changes[0]
+			if c.Kind != tt.kind || c.Path != tt.path || !strings.Contains(c.Message, tt.message) {
+				t.Errorf("expected %s change at %s containing %q, got %s change %q", tt.kind, tt.path, tt.message, c.Kind, c.Error())
+			}
+		})
+	}
+}
+
+func TestCompareIdentitySchemas_SortedBySeverity(t *testing.T) {
+	changes := CompareIdentitySchemas(
+		parseJSON(t, `{"properties":{"traits":{"properties":{"a":{"type":"string"}}}}}`),
+		parseJSON(t, `{"properties":{"traits":{"properties":{"a":{"type":"string","format":"email"},"b":{"type":"string"},"c":{"type":"string"}},"required":["c"]}}}`),
+	)
+	var kinds []string
+	for _, c := range changes {
+		kinds = append(kinds, c.Kind)
+	}
+	if strings.Join(kinds, ",") != "breaking,narrowing,additive" {
+		t.Errorf("expected breaking, narrowing, additive order, got %v", changes)
+	}
+}
@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// TraitError is a schema violation at a specific trait.
+type TraitError struct {
+	// Path is the dotted path of the trait, e.g. "traits.name.first".
+	Path    string
+	Message string
+}
+
+func (e TraitError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+var missingPropertiesPattern = regexp.MustCompile(`^missing properties: (.+)$`)
+
+// ValidateTraits validates identity traits against an identity schema the
+// same way Ory Kratos does, by validating {"traits": traits} against the
+// whole schema. Schema violations are returned as TraitErrors sorted by
+// path. An error is returned if the schema itself cannot be compiled, e.g.
+// because it references remote documents.
+func ValidateTraits(identitySchema map[string]interface{}, traits interface{}) ([]TraitError, error) {
+	raw, err := json.Marshal(identitySchema)
+	if err != nil {
+		return nil, fmt.Errorf("encoding identity schema: %w", err)
+	}
+
+	const schemaURL = "identity.schema.json"
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("remote schema references are not supported: %s", s)
+	}
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("loading identity schema: %w", err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("compiling identity schema: %w", err)
+	}
+
+	err = compiled.Validate(map[string]interface{}{"traits": traits})
+	if err == nil {
+		return nil, nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var result []TraitError
+	seen := map[string]bool{}
+	add := func(p, message string) {
+		if key := p + "\x00" + message; !seen[key] {
+			seen[key] = true
+			result = append(result, TraitError{Path: p, Message: message})
+		}
+	}
+
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+
+		p := traitPath(e.InstanceLocation)
+		if m := missingPropertiesPattern.FindStringSubmatch(e.Message); m != nil {
+			for _, name := range strings.Split(m[1], ", ") {
+				add(p+"."+strings.Trim(name, `'"`), "is required")
+			}
+			return
+		}
+		add(p, e.Message)
+	}
+	walk(validationErr)
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// traitPath converts a JSON pointer into the instance ("/traits/name/first")
+// to a dotted path ("traits.name.first").
+func traitPath(pointer string) string {
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+	}
+	p := strings.Join(parts, ".")
+	if p == "" {
+		return "traits"
+	}
+	return p
+}
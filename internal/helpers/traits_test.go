@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testIdentitySchema = `{
+  "$id": "https://example.com/customer.schema.json",
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "traits": {
+      "type": "object",
+      "properties": {
+        "email": {
+          "type": "string",
+          "format": "email",
+          "ory.sh/kratos": {"credentials": {"password": {"identifier": true}}}
+        },
+        "name": {
+          "type": "object",
+          "properties": {
+            "first": {"type": "string", "minLength": 1},
+            "last": {"type": "string"}
+          },
+          "required": ["first"]
+        },
+        "age": {"type": "integer", "minimum": 0}
+      },
+      "required": ["email", "name"],
+      "additionalProperties": false
+    }
+  }
+}`
+
+func parseJSON(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+	return v
+}
+
+func TestValidateTraits_Valid(t *testing.T) {
+	errs, err := ValidateTraits(parseJSON(t, testIdentitySchema), parseJSON(t, `{"email":"jane@example.com","name":{"first":"Jane"},"age":30}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) > 0 {
+		t.Errorf("unexpected trait errors: %v", errs)
+	}
+}
+
+func TestValidateTraits_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		traits  string
+		path    string
+		message string
+	}{
+		{"bad email", `{"email":"not-an-email","name":{"first":"Jane"}}`, "traits.email", "not valid"},
+		{"missing required", `{"name":{"first":"Jane"}}`, "traits.email", "is required"},
+		{"missing nested required", `{"email":"jane@example.com","name":{}}`, "traits.name.first", "is required"},
+		{"wrong type", `{"email":"jane@example.com","name":{"first":"Jane"},"age":"old"}`, "traits.age", "expected integer"},
+		{"additional property", `{"email":"jane@example.com","name":{"first":"Jane"},"phone":"1"}`, "traits", "additionalProperties 'phone' not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := ValidateTraits(parseJSON(t, testIdentitySchema), parseJSON(t, tt.traits))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 trait error, got %v", errs)
+			}
+			if errs[0].Path != tt.path {
+				t.Errorf("expected path %q, got %q", tt.path, errs[0].Path)
+			}
+			if !strings.Contains(errs[0].Message, tt.message) {
+				t.Errorf("expected message containing %q, got %q", tt.message, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestValidateTraits_MultipleErrors(t *testing.T) {
+	errs, err := ValidateTraits(parseJSON(t, testIdentitySchema), parseJSON(t, `{"email":"nope","name":{}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 2 || errs[0].Path != "traits.email" || errs[1].Path != "traits.name.first" {
+		t.Errorf("expected errors for traits.email and traits.name.first, got %v", errs)
+	}
+}
+
+func TestValidateTraits_RemoteReference(t *testing.T) {
+	schema := parseJSON(t, `{"properties":{"traits":{"$ref":"https://example.com/traits.json"}}}`)
+	if _, err := ValidateTraits(schema, map[string]interface{}{}); err == nil {
+		t.Error("expected error for remote reference")
+	}
+}
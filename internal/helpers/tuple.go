@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	ory "github.com/ory/client-go"
+)
+
+// ParseRelationTuple parses a relation tuple in Zanzibar notation:
+//
+//	namespace:object#relation@subject
+//
+// The subject is either a subject ID (user-1), a subject set without a
+// relation (User:alice), or a subject set with a relation, optionally wrapped
+// in parentheses (Group:admins#members or (Group:admins#members)).
+func ParseRelationTuple(s string) (ory.Relationship, error) {
+	var rel ory.Relationship
+
+	head, subject, ok := strings.Cut(s, "@")
+	if !ok {
+		return rel, fmt.Errorf("invalid relation tuple %q: missing '@' before the subject (expected namespace:object#relation@subject)", s)
+	}
+
+	namespace, rest, ok := strings.Cut(head, ":")
+	if !ok {
+		return rel, fmt.Errorf("invalid relation tuple %q: missing ':' between namespace and object", s)
+	}
+	object, relation, ok := strings.Cut(rest, "#")
+	if !ok {
+		return rel, fmt.Errorf("invalid relation tuple %q: missing '#' before the relation", s)
+	}
+	if namespace == "" || object == "" || relation == "" {
+		return rel, fmt.Errorf("invalid relation tuple %q: namespace, object, and relation must not be empty", s)
+	}
+
+	rel.Namespace = namespace
+	rel.Object = object
+	rel.Relation = relation
+
+	if strings.HasPrefix(subject, "(") || strings.HasSuffix(subject, ")") {
+		if !strings.HasPrefix(subject, "(") || !strings.HasSuffix(subject, ")") {
+			return rel, fmt.Errorf("invalid relation tuple %q: unbalanced parentheses around the subject", s)
+		}
+		subject = subject[1 : len(subject)-1]
+		if !strings.Contains(subject, ":") {
+			return rel, fmt.Errorf("invalid relation tuple %q: a parenthesized subject must be a subject set (namespace:object#relation)", s)
+		}
+	}
+	if subject == "" {
+		return rel, fmt.Errorf("invalid relation tuple %q: subject must not be empty", s)
+	}
+
+	setNamespace, setRest, isSet := strings.Cut(subject, ":")
+	if !isSet {
+		if strings.Contains(subject, "#") {
+			return rel, fmt.Errorf("invalid relation tuple %q: subject %q has a relation but no namespace", s, subject)
+		}
+		rel.SubjectId = ory.PtrString(subject)
+		return rel, nil
+	}
+
+	setObject, setRelation, hasRelation := strings.Cut(setRest, "#")
+	if setNamespace == "" || setObject == "" || (hasRelation && setRelation == "") {
+		return rel, fmt.Errorf("invalid relation tuple %q: subject set %q must be namespace:object or namespace:object#relation", s, subject)
+	}
+	rel.SubjectSet = &ory.SubjectSet{
+		Namespace: setNamespace,
+		Object:    setObject,
+		Relation:  setRelation,
+	}
+	return rel, nil
+}
+
+// FormatRelationTuple formats a relationship in canonical Zanzibar notation.
+// Subject sets with a relation are wrapped in parentheses, e.g.
+// Doc:1#viewers@(Group:admins#members).
+func FormatRelationTuple(rel ory.Relationship) string {
+	subject := ""
+	switch {
+	case rel.SubjectId != nil:
+		subject = *rel.SubjectId
+	case rel.SubjectSet != nil && rel.SubjectSet.Relation == "":
+		subject = fmt.Sprintf("%s:%s", rel.SubjectSet.Namespace, rel.SubjectSet.Object)
+	case rel.SubjectSet != nil:
+		subject = fmt.Sprintf("(%s:%s#%s)", rel.SubjectSet.Namespace, rel.SubjectSet.Object, rel.SubjectSet.Relation)
+	}
+	return fmt.Sprintf("%s:%s#%s@%s", rel.Namespace, rel.Object, rel.Relation, subject)
+}
@@ -0,0 +1,94 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	ory "github.com/ory/client-go"
+)
+
+func TestParseRelationTuple(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  ory.Relationship
+		canonical string
+	}{
+		{
+			input: "documents:doc-123#viewer@user-456",
+			expected: ory.Relationship{
+				Namespace: "documents", Object: "doc-123", Relation: "viewer",
+				SubjectId: ory.PtrString("user-456"),
+			},
+			canonical: "documents:doc-123#viewer@user-456",
+		},
+		{
+			input: "Group:admins#members@User:alice",
+			expected: ory.Relationship{
+				Namespace: "Group", Object: "admins", Relation: "members",
+				SubjectSet: &ory.SubjectSet{Namespace: "User", Object: "alice"},
+			},
+			canonical: "Group:admins#members@User:alice",
+		},
+		{
+			input: "Doc:1#viewers@(Group:admins#members)",
+			expected: ory.Relationship{
+				Namespace: "Doc", Object: "1", Relation: "viewers",
+				SubjectSet: &ory.SubjectSet{Namespace: "Group", Object: "admins", Relation: "members"},
+			},
+			canonical: "Doc:1#viewers@(Group:admins#members)",
+		},
+		{
+			// Unparenthesized subject sets (the legacy import ID format) are accepted
+			input: "documents:doc-123#viewer@folders:folder-789#editor",
+			expected: ory.Relationship{
+				Namespace: "documents", Object: "doc-123", Relation: "viewer",
+				SubjectSet: &ory.SubjectSet{Namespace: "folders", Object: "folder-789", Relation: "editor"},
+			},
+			canonical: "documents:doc-123#viewer@(folders:folder-789#editor)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rel, err := ParseRelationTuple(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if FormatRelationTuple(rel) != FormatRelationTuple(tt.expected) {
+				t.Errorf("expected %s, got %s", FormatRelationTuple(tt.expected), FormatRelationTuple(rel))
+			}
+			if got := FormatRelationTuple(rel); got != tt.canonical {
+				t.Errorf("expected canonical form %q, got %q", tt.canonical, got)
+			}
+		})
+	}
+}
+
+func TestParseRelationTuple_Invalid(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"documents:doc-123#viewer", "missing '@'"},
+		{"documents#viewer@user", "missing ':'"},
+		{"documents:doc-123@user", "missing '#'"},
+		{":doc-123#viewer@user", "must not be empty"},
+		{"documents:doc-123#viewer@", "subject must not be empty"},
+		{"documents:doc-123#viewer@(Group:admins#members", "unbalanced parentheses"},
+		{"documents:doc-123#viewer@(user-1)", "must be a subject set"},
+		{"documents:doc-123#viewer@admins#members", "no namespace"},
+		{"documents:doc-123#viewer@Group:admins#", "must be namespace:object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseRelationTuple(tt.input)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("expected error containing %q, got %q", tt.message, err.Error())
+			}
+		})
+	}
+}
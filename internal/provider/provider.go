@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -13,24 +15,36 @@ import (
 
 	"github.com/ory/terraform-provider-ory/internal/client"
 	identityds "github.com/ory/terraform-provider-ory/internal/datasources/identity"
+	identityschemads "github.com/ory/terraform-provider-ory/internal/datasources/identityschema"
 	identityschemasds "github.com/ory/terraform-provider-ory/internal/datasources/identityschemas"
+	identitysessionsds "github.com/ory/terraform-provider-ory/internal/datasources/identitysessions"
 	oauth2clientds "github.com/ory/terraform-provider-ory/internal/datasources/oauth2client"
 	organizationds "github.com/ory/terraform-provider-ory/internal/datasources/organization"
+	permissioncheckds "github.com/ory/terraform-provider-ory/internal/datasources/permissioncheck"
 	projectds "github.com/ory/terraform-provider-ory/internal/datasources/project"
+	relationshipsds "github.com/ory/terraform-provider-ory/internal/datasources/relationships"
 	workspaceds "github.com/ory/terraform-provider-ory/internal/datasources/workspace"
+	"github.com/ory/terraform-provider-ory/internal/ephemeralresources/identityrecovery"
+	"github.com/ory/terraform-provider-ory/internal/functions/evaluatejsonnet"
 	"github.com/ory/terraform-provider-ory/internal/resources/action"
 	"github.com/ory/terraform-provider-ory/internal/resources/emailtemplate"
 	"github.com/ory/terraform-provider-ory/internal/resources/eventstream"
+	"github.com/ory/terraform-provider-ory/internal/resources/flowhook"
+	"github.com/ory/terraform-provider-ory/internal/resources/flowhooks"
 	"github.com/ory/terraform-provider-ory/internal/resources/identity"
+	"github.com/ory/terraform-provider-ory/internal/resources/identityimport"
 	"github.com/ory/terraform-provider-ory/internal/resources/identityschema"
 	"github.com/ory/terraform-provider-ory/internal/resources/jwk"
 	"github.com/ory/terraform-provider-ory/internal/resources/oauth2client"
 	"github.com/ory/terraform-provider-ory/internal/resources/oidcdynamicclient"
 	"github.com/ory/terraform-provider-ory/internal/resources/organization"
+	"github.com/ory/terraform-provider-ory/internal/resources/permissionmodel"
 	"github.com/ory/terraform-provider-ory/internal/resources/project"
 	"github.com/ory/terraform-provider-ory/internal/resources/projectapikey"
 	"github.com/ory/terraform-provider-ory/internal/resources/projectconfig"
 	"github.com/ory/terraform-provider-ory/internal/resources/relationship"
+	"github.com/ory/terraform-provider-ory/internal/resources/relationships"
+	"github.com/ory/terraform-provider-ory/internal/resources/samlprovider"
 	"github.com/ory/terraform-provider-ory/internal/resources/socialprovider"
 	"github.com/ory/terraform-provider-ory/internal/resources/trustedjwtissuer"
 	"github.com/ory/terraform-provider-ory/internal/resources/workspace"
@@ -43,7 +57,11 @@ const (
 )
 
 // Ensure OryProvider satisfies various provider interfaces.
-var _ provider.Provider = &OryProvider{}
+var (
+	_ provider.Provider                       = &OryProvider{}
+	_ provider.ProviderWithEphemeralResources = &OryProvider{}
+	_ provider.ProviderWithFunctions          = &OryProvider{}
+)
 
 // OryProvider defines the provider implementation.
 type OryProvider struct {
@@ -269,6 +287,7 @@ For more information: https://www.ory.sh/docs/guides/api-keys`,
 
 	resp.DataSourceData = p.oryClient
 	resp.ResourceData = p.oryClient
+	resp.EphemeralResourceData = p.oryClient
 }
 
 func (p *OryProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -277,15 +296,21 @@ func (p *OryProvider) Resources(ctx context.Context) []func() resource.Resource
 		workspace.NewResource,
 		organization.NewResource,
 		identity.NewResource,
+		identityimport.NewResource,
 		oauth2client.NewResource,
 		projectconfig.NewResource,
 		action.NewResource,
+		flowhook.NewResource,
+		flowhooks.NewResource,
 		identityschema.NewResource,
 		socialprovider.NewResource,
+		samlprovider.NewResource,
 		emailtemplate.NewResource,
 		projectapikey.NewResource,
 		jwk.NewResource,
 		relationship.NewResource,
+		relationships.NewResource,
+		permissionmodel.NewResource,
 		eventstream.NewResource,
 		trustedjwtissuer.NewResource,
 		oidcdynamicclient.NewResource,
@@ -299,7 +324,23 @@ func (p *OryProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		identityds.NewDataSource,
 		oauth2clientds.NewDataSource,
 		organizationds.NewDataSource,
+		identityschemads.NewDataSource,
 		identityschemasds.NewDataSource,
+		identitysessionsds.NewDataSource,
+		permissioncheckds.NewDataSource,
+		relationshipsds.NewDataSource,
+	}
+}
+
+func (p *OryProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		identityrecovery.NewEphemeralResource,
+	}
+}
+
+func (p *OryProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		evaluatejsonnet.NewFunction,
 	}
 }
 
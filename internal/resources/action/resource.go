@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
@@ -32,9 +33,10 @@ const (
 )
 
 var (
-	_ resource.Resource                = &ActionResource{}
-	_ resource.ResourceWithConfigure   = &ActionResource{}
-	_ resource.ResourceWithImportState = &ActionResource{}
+	_ resource.Resource                   = &ActionResource{}
+	_ resource.ResourceWithConfigure      = &ActionResource{}
+	_ resource.ResourceWithImportState    = &ActionResource{}
+	_ resource.ResourceWithValidateConfig = &ActionResource{}
 )
 
 func NewResource() resource.Resource {
@@ -57,6 +59,17 @@ type ActionResourceModel struct {
 	ResponseIgnore types.Bool   `tfsdk:"response_ignore"`
 	ResponseParse  types.Bool   `tfsdk:"response_parse"`
 	CanInterrupt   types.Bool   `tfsdk:"can_interrupt"`
+	Auth           *AuthModel   `tfsdk:"auth"`
+}
+
+// AuthModel describes how the webhook authenticates against the receiver.
+type AuthModel struct {
+	Type     types.String `tfsdk:"type"`
+	User     types.String `tfsdk:"user"`
+	Password types.String `tfsdk:"password"`
+	Name     types.String `tfsdk:"name"`
+	Value    types.String `tfsdk:"value"`
+	In       types.String `tfsdk:"in"`
 }
 
 const actionMarkdownDescription = `
@@ -196,8 +209,11 @@ func (r *ActionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:     stringdefault.StaticString("POST"),
 			},
 			"body": schema.StringAttribute{
-				Description: "Jsonnet template for the request body.",
+				Description: "Jsonnet template for the request body. Checked for syntax errors at plan time.",
 				Optional:    true,
+				Validators: []validator.String{
+					helpers.JsonnetValidator(),
+				},
 			},
 			"response_ignore": schema.BoolAttribute{
 				Description: "Run webhook async without waiting (default: false).",
@@ -217,6 +233,46 @@ func (r *ActionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"auth": schema.SingleNestedAttribute{
+				Description: "Authentication for the webhook request. Secrets are stored in the Terraform state but not read back from the API; changes to them outside Terraform are not detected.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "Authentication type: 'basic_auth' or 'api_key'.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("basic_auth", "api_key"),
+						},
+					},
+					"user": schema.StringAttribute{
+						Description: "Username for basic_auth.",
+						Optional:    true,
+					},
+					"password": schema.StringAttribute{
+						Description: "Password for basic_auth. Stored in the Terraform state; protect the state accordingly.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"name": schema.StringAttribute{
+						Description: "Header or cookie name for api_key auth.",
+						Optional:    true,
+					},
+					"value": schema.StringAttribute{
+						Description: "API key value for api_key auth. Stored in the Terraform state; protect the state accordingly.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"in": schema.StringAttribute{
+						Description: "Where to send the API key: 'header' (default) or 'cookie'. Only for api_key auth.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("header"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("header", "cookie"),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -234,6 +290,40 @@ func (r *ActionResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = oryClient
 }
 
+func (r *ActionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// auth may be unknown as a whole (e.g. auth = var.x), which AuthModel
+	// cannot hold, so check it as an object first.
+	var authObject types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth"), &authObject)...)
+	if resp.Diagnostics.HasError() || authObject.IsNull() || authObject.IsUnknown() {
+		return
+	}
+
+	var auth AuthModel
+	resp.Diagnostics.Append(authObject.As(ctx, &auth, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || auth.Type.IsUnknown() {
+		return
+	}
+
+	required := map[string]types.String{"user": auth.User, "password": auth.Password}
+	unused := map[string]types.String{"name": auth.Name, "value": auth.Value, "in": auth.In}
+	if auth.Type.ValueString() == "api_key" {
+		required = map[string]types.String{"name": auth.Name, "value": auth.Value}
+		unused = map[string]types.String{"user": auth.User, "password": auth.Password}
+	}
+
+	for _, name := range []string{"user", "password", "name", "value", "in"} {
+		if v, ok := required[name]; ok && v.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("auth").AtName(name), "Missing Attribute Configuration",
+				fmt.Sprintf("auth.%s is required when auth.type is %q.", name, auth.Type.ValueString()))
+		}
+		if v, ok := unused[name]; ok && !v.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("auth").AtName(name), "Invalid Attribute Combination",
+				fmt.Sprintf("auth.%s cannot be used when auth.type is %q.", name, auth.Type.ValueString()))
+		}
+	}
+}
+
 func (r *ActionResource) buildHookValue(plan *ActionResourceModel) map[string]interface{} {
 	hookConfig := map[string]interface{}{
 		"url":    plan.URL.ValueString(),
@@ -264,12 +354,75 @@ func (r *ActionResource) buildHookValue(plan *ActionResourceModel) map[string]in
 		hookConfig["can_interrupt"] = plan.CanInterrupt.ValueBool()
 	}
 
+	if plan.Auth != nil {
+		hookConfig["auth"] = buildAuthValue(plan.Auth)
+	}
+
 	return map[string]interface{}{
 		"hook":   "web_hook",
 		"config": hookConfig,
 	}
 }
 
+// buildAuthValue converts the flat auth attributes to the nested API format:
+// {"type": "...", "config": {...}}
+func buildAuthValue(auth *AuthModel) map[string]interface{} {
+	authType := auth.Type.ValueString()
+	config := map[string]interface{}{}
+	switch authType {
+	case "basic_auth":
+		config["user"] = auth.User.ValueString()
+		config["password"] = auth.Password.ValueString()
+	case "api_key":
+		config["name"] = auth.Name.ValueString()
+		config["value"] = auth.Value.ValueString()
+		config["in"] = auth.In.ValueString()
+	}
+	return map[string]interface{}{
+		"type":   authType,
+		"config": config,
+	}
+}
+
+// readAuth reads the webhook auth from the API. Secrets are kept from state,
+// so they never show up as drift and are not copied from the API into state.
+func readAuth(raw map[string]interface{}, state *AuthModel) *AuthModel {
+	authType, _ := raw["type"].(string)
+	if authType == "" {
+		return nil
+	}
+	config, _ := raw["config"].(map[string]interface{})
+
+	auth := &AuthModel{
+		Type:     types.StringValue(authType),
+		User:     types.StringNull(),
+		Password: types.StringNull(),
+		Name:     types.StringNull(),
+		Value:    types.StringNull(),
+		In:       types.StringValue("header"),
+	}
+	switch authType {
+	case "basic_auth":
+		if s, ok := config["user"].(string); ok {
+			auth.User = types.StringValue(s)
+		}
+		if state != nil {
+			auth.Password = state.Password
+		}
+	case "api_key":
+		if s, ok := config["name"].(string); ok {
+			auth.Name = types.StringValue(s)
+		}
+		if s, ok := config["in"].(string); ok && s != "" {
+			auth.In = types.StringValue(s)
+		}
+		if state != nil {
+			auth.Value = state.Value
+		}
+	}
+	return auth
+}
+
 func (r *ActionResource) getHooks(ctx context.Context, projectID, flow, timing, authMethod string) ([]map[string]interface{}, error) {
 	project, err := r.client.GetProject(ctx, projectID)
 	if err != nil {
@@ -602,6 +755,10 @@ func (r *ActionResource) Read(ctx context.Context, req resource.ReadRequest, res
 		state.CanInterrupt = types.BoolValue(false)
 	}
 
+	// Read auth, keeping secrets from state
+	authRaw, _ := config["auth"].(map[string]interface{})
+	state.Auth = readAuth(authRaw, state.Auth)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -4,6 +4,7 @@ package action_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -51,3 +52,88 @@ func TestAccActionResource_basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccActionResource_auth(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/auth.tf.tmpl", map[string]string{"WebhookURL": testutil.ExampleWebhookURL, "APIKey": "secret-1"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_action.test", "auth.type", "api_key"),
+					resource.TestCheckResourceAttr("ory_action.test", "auth.name", "X-API-Key"),
+					resource.TestCheckResourceAttr("ory_action.test", "auth.in", "header"),
+					resource.TestCheckResourceAttr("ory_action.test", "auth.value", "secret-1"),
+				),
+			},
+			// Rotating the key updates the hook in place
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/auth.tf.tmpl", map[string]string{"WebhookURL": testutil.ExampleWebhookURL, "APIKey": "secret-2"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_action.test", "auth.value", "secret-2"),
+				),
+			},
+			// Secrets are not imported
+			{
+				ResourceName: "ory_action.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["ory_action.test"]
+					if !ok {
+						return "", fmt.Errorf("resource not found: ory_action.test")
+					}
+					a := rs.Primary.Attributes
+					return fmt.Sprintf("%s:%s:%s:%s:%s:%s", a["project_id"], a["flow"], a["timing"], a["auth_method"], a["method"], a["url"]), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auth.value"},
+			},
+		},
+	})
+}
+
+func TestAccActionResource_invalidAuth(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_auth.tf.tmpl", map[string]string{"WebhookURL": testutil.ExampleWebhookURL}),
+				ExpectError: regexp.MustCompile(`auth.password is required`),
+			},
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_auth.tf.tmpl", map[string]string{"WebhookURL": testutil.ExampleWebhookURL}),
+				ExpectError: regexp.MustCompile(`auth.in cannot be used when auth.type is "basic_auth"`),
+			},
+		},
+	})
+}
+
+func TestAccActionResource_unknownAuth(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// auth is unknown until terraform_data.auth is created
+			{
+				Config:             acctest.LoadTestConfig(t, "testdata/unknown_auth.tf.tmpl", map[string]string{"WebhookURL": testutil.ExampleWebhookURL}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccActionResource_invalidBody(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_body.tf.tmpl", map[string]string{"WebhookURL": testutil.ExampleWebhookURL}),
+				ExpectError: regexp.MustCompile(`Invalid Jsonnet`),
+			},
+		},
+	})
+}
@@ -0,0 +1,446 @@
+package flowhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+var (
+	_ resource.Resource                   = &FlowHookResource{}
+	_ resource.ResourceWithConfigure      = &FlowHookResource{}
+	_ resource.ResourceWithImportState    = &FlowHookResource{}
+	_ resource.ResourceWithValidateConfig = &FlowHookResource{}
+)
+
+// flowHooks lists the built-in hooks each flow supports after an
+// authentication method completed.
+var flowHooks = map[string][]string{
+	"registration": {"session", "show_verification_ui", "b2b_sso"},
+	"login":        {"revoke_active_sessions", "require_verified_address", "b2b_sso"},
+	"recovery":     {"revoke_active_sessions"},
+	"settings":     {"revoke_active_sessions"},
+}
+
+var hookNames = []string{"session", "show_verification_ui", "revoke_active_sessions", "require_verified_address", "b2b_sso"}
+
+func NewResource() resource.Resource {
+	return &FlowHookResource{}
+}
+
+type FlowHookResource struct {
+	client *client.OryClient
+}
+
+type FlowHookResourceModel struct {
+	ID         types.String           `tfsdk:"id"`
+	ProjectID  types.String           `tfsdk:"project_id"`
+	Flow       types.String           `tfsdk:"flow"`
+	AuthMethod types.String           `tfsdk:"auth_method"`
+	Hook       types.String           `tfsdk:"hook"`
+	Config     helpers.NormalizedJSON `tfsdk:"config"`
+}
+
+func (r *FlowHookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_hook"
+}
+
+func (r *FlowHookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a built-in (non-webhook) Ory hook that runs after an identity flow, such as issuing a session after registration.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID in the format project_id:flow:auth_method:hook.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Project ID. If not set, uses provider's project_id.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"flow": schema.StringAttribute{
+				Description: "Identity flow to hook into (login, registration, recovery, settings).",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("login", "registration", "recovery", "settings"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auth_method": schema.StringAttribute{
+				Description: "Authentication method the hook runs after (password, oidc, code, webauthn, passkey, totp, lookup_secret). Defaults to 'password'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("password"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("password", "oidc", "code", "webauthn", "passkey", "totp", "lookup_secret"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hook": schema.StringAttribute{
+				Description: "The built-in hook: session, show_verification_ui, revoke_active_sessions, require_verified_address or b2b_sso.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(hookNames...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.StringAttribute{
+				Description: "Hook configuration as JSON string, for hooks that take one.",
+				CustomType:  helpers.NormalizedJSONType{},
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *FlowHookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	r.client = oryClient
+}
+
+func (r *FlowHookResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config FlowHookResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Flow.IsUnknown() || config.Hook.IsUnknown() || config.Flow.IsNull() || config.Hook.IsNull() {
+		return
+	}
+
+	flow, hook := config.Flow.ValueString(), config.Hook.ValueString()
+	if supported, ok := flowHooks[flow]; ok && !slices.Contains(supported, hook) {
+		resp.Diagnostics.AddAttributeError(path.Root("hook"), "Unsupported Flow Hook",
+			fmt.Sprintf("The %s flow does not support the %s hook. Supported hooks: %s.", flow, hook, strings.Join(supported, ", ")))
+	}
+}
+
+func hookPath(flow, authMethod string) string {
+	return fmt.Sprintf("/services/identity/config/selfservice/flows/%s/after/%s/hooks", flow, authMethod)
+}
+
+// hooksFromProject returns the hooks that run after authMethod completed in
+// flow, webhooks included.
+func hooksFromProject(project *ory.Project, flow, authMethod string) []map[string]interface{} {
+	if project.Services.Identity == nil {
+		return []map[string]interface{}{}
+	}
+
+	selfservice, _ := project.Services.Identity.Config["selfservice"].(map[string]interface{})
+	flows, _ := selfservice["flows"].(map[string]interface{})
+	flowConfig, _ := flows[flow].(map[string]interface{})
+	after, _ := flowConfig["after"].(map[string]interface{})
+	authMethodConfig, _ := after[authMethod].(map[string]interface{})
+	hooks, _ := authMethodConfig["hooks"].([]interface{})
+
+	result := make([]map[string]interface{}, 0, len(hooks))
+	for _, h := range hooks {
+		if hm, ok := h.(map[string]interface{}); ok {
+			result = append(result, hm)
+		}
+	}
+	return result
+}
+
+func (r *FlowHookResource) getHooks(ctx context.Context, projectID, flow, authMethod string) ([]map[string]interface{}, error) {
+	project, err := r.client.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+	}
+	return hooksFromProject(project, flow, authMethod), nil
+}
+
+func findHookIndex(hooks []map[string]interface{}, hook string) int {
+	for i, h := range hooks {
+		if h["hook"] == hook {
+			return i
+		}
+	}
+	return -1
+}
+
+func buildHookValue(plan *FlowHookResourceModel) (map[string]interface{}, error) {
+	value := map[string]interface{}{
+		"hook": plan.Hook.ValueString(),
+	}
+	if !plan.Config.IsNull() && !plan.Config.IsUnknown() {
+		var config map[string]interface{}
+		if err := plan.Config.Unmarshal(&config); err != nil {
+			return nil, fmt.Errorf("config must be a JSON object: %w", err)
+		}
+		value["config"] = config
+	}
+	return value, nil
+}
+
+func flowHookID(projectID, flow, authMethod, hook string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", projectID, flow, authMethod, hook)
+}
+
+func (r *FlowHookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FlowHookResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flow := plan.Flow.ValueString()
+	authMethod := plan.AuthMethod.ValueString()
+	hook := plan.Hook.ValueString()
+
+	hookValue, err := buildHookValue(&plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Invalid Hook Config", err.Error())
+		return
+	}
+
+	hooks, err := r.getHooks(ctx, projectID, flow, authMethod)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Getting Hooks", err.Error())
+		return
+	}
+
+	if findHookIndex(hooks, hook) >= 0 {
+		resp.Diagnostics.AddError("Hook Already Exists",
+			fmt.Sprintf("The %s hook is already configured for %s/after/%s. Import it with: terraform import <address> %s",
+				hook, flow, authMethod, flowHookID(projectID, flow, authMethod, hook)))
+		return
+	}
+
+	// Append the new hook to existing hooks and replace the entire array,
+	// which also creates the hooks array if it does not exist yet.
+	newHooks := make([]interface{}, 0, len(hooks)+1)
+	for _, h := range hooks {
+		newHooks = append(newHooks, h)
+	}
+	newHooks = append(newHooks, hookValue)
+
+	patches := []ory.JsonPatch{{
+		Op:    "replace",
+		Path:  hookPath(flow, authMethod),
+		Value: newHooks,
+	}}
+
+	result, err := r.client.PatchProject(ctx, projectID, patches)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Flow Hook", err.Error())
+		return
+	}
+
+	project := result.GetProject()
+	if findHookIndex(hooksFromProject(&project, flow, authMethod), hook) < 0 {
+		resp.Diagnostics.AddError("Error Verifying Flow Hook",
+			fmt.Sprintf("Hook %s not found in PatchProject response for %s/after/%s", hook, flow, authMethod))
+		return
+	}
+
+	plan.ID = types.StringValue(flowHookID(projectID, flow, authMethod, hook))
+	plan.ProjectID = types.StringValue(projectID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *FlowHookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FlowHookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := state.ProjectID.ValueString()
+	flow := state.Flow.ValueString()
+	authMethod := state.AuthMethod.ValueString()
+	hook := state.Hook.ValueString()
+
+	var hooks []map[string]interface{}
+	index := -1
+
+	if cached := r.client.GetCachedProject(projectID); cached != nil {
+		hooks = hooksFromProject(cached, flow, authMethod)
+		index = findHookIndex(hooks, hook)
+	}
+
+	if index < 0 {
+		var err error
+		for attempt := 0; attempt < helpers.ReadRetryMaxAttempts; attempt++ {
+			hooks, err = r.getHooks(ctx, projectID, flow, authMethod)
+			if err != nil {
+				resp.Diagnostics.AddError("Error Reading Flow Hook", err.Error())
+				return
+			}
+
+			index = findHookIndex(hooks, hook)
+			if index >= 0 {
+				break
+			}
+
+			if attempt < helpers.ReadRetryMaxAttempts-1 {
+				select {
+				case <-ctx.Done():
+					resp.State.RemoveResource(ctx)
+					return
+				case <-time.After(time.Duration(1<<attempt) * time.Second):
+				}
+			}
+		}
+	}
+
+	if index < 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Hooks without configuration keep a null config, even if the API
+	// returns an empty object.
+	if config, ok := hooks[index]["config"].(map[string]interface{}); ok && (len(config) > 0 || !state.Config.IsNull()) {
+		configJSON, err := helpers.NewNormalizedJSONFromObject(config)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Flow Hook", fmt.Sprintf("Could not encode hook config: %s", err.Error()))
+			return
+		}
+		state.Config = configJSON
+	} else {
+		state.Config = helpers.NewNormalizedJSONNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *FlowHookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FlowHookResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flow := plan.Flow.ValueString()
+	authMethod := plan.AuthMethod.ValueString()
+	hook := plan.Hook.ValueString()
+
+	hookValue, err := buildHookValue(&plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Invalid Hook Config", err.Error())
+		return
+	}
+
+	hooks, err := r.getHooks(ctx, projectID, flow, authMethod)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Getting Hooks", err.Error())
+		return
+	}
+
+	index := findHookIndex(hooks, hook)
+	if index < 0 {
+		resp.Diagnostics.AddError("Hook Not Found",
+			fmt.Sprintf("The %s hook is not configured for %s/after/%s", hook, flow, authMethod))
+		return
+	}
+
+	patches := []ory.JsonPatch{{
+		Op:    "replace",
+		Path:  fmt.Sprintf("%s/%d", hookPath(flow, authMethod), index),
+		Value: hookValue,
+	}}
+
+	if _, err := r.client.PatchProject(ctx, projectID, patches); err != nil {
+		resp.Diagnostics.AddError("Error Updating Flow Hook", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(flowHookID(projectID, flow, authMethod, hook))
+	plan.ProjectID = types.StringValue(projectID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *FlowHookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FlowHookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := state.ProjectID.ValueString()
+	flow := state.Flow.ValueString()
+	authMethod := state.AuthMethod.ValueString()
+
+	hooks, err := r.getHooks(ctx, projectID, flow, authMethod)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Getting Hooks", err.Error())
+		return
+	}
+
+	index := findHookIndex(hooks, state.Hook.ValueString())
+	if index < 0 {
+		return // Already deleted
+	}
+
+	patches := []ory.JsonPatch{{
+		Op:   "remove",
+		Path: fmt.Sprintf("%s/%d", hookPath(flow, authMethod), index),
+	}}
+
+	if _, err := r.client.PatchProject(ctx, projectID, patches); err != nil {
+		resp.Diagnostics.AddError("Error Deleting Flow Hook", err.Error())
+		return
+	}
+}
+
+func (r *FlowHookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 4 || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError("Invalid Import ID",
+			"Import ID must be in the format project_id:flow:auth_method:hook, e.g. 550e8400-...:registration:password:session")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("flow"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("auth_method"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hook"), parts[3])...)
+}
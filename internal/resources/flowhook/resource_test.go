@@ -0,0 +1,62 @@
+//go:build acceptance
+
+package flowhook_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccFlowHookResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", nil),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_flow_hook.test", "id"),
+					resource.TestCheckResourceAttr("ory_flow_hook.test", "flow", "login"),
+					resource.TestCheckResourceAttr("ory_flow_hook.test", "auth_method", "password"),
+					resource.TestCheckResourceAttr("ory_flow_hook.test", "hook", "revoke_active_sessions"),
+					resource.TestCheckNoResourceAttr("ory_flow_hook.test", "config"),
+				),
+			},
+			// Import using project_id:flow:auth_method:hook
+			{
+				ResourceName:      "ory_flow_hook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing config updates the hook in place, keeping its position
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_config.tf.tmpl", nil),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ory_flow_hook.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ory_flow_hook.test", "config", "{}"),
+			},
+		},
+	})
+}
+
+func TestAccFlowHookResource_unsupportedHook(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_hook.tf.tmpl", nil),
+				ExpectError: regexp.MustCompile(`Unsupported Flow Hook`),
+			},
+		},
+	})
+}
@@ -0,0 +1,417 @@
+package flowhooks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+const (
+	timingBefore = "before"
+	timingAfter  = "after"
+)
+
+var (
+	_ resource.Resource                = &FlowHooksResource{}
+	_ resource.ResourceWithConfigure   = &FlowHooksResource{}
+	_ resource.ResourceWithImportState = &FlowHooksResource{}
+)
+
+func NewResource() resource.Resource {
+	return &FlowHooksResource{}
+}
+
+type FlowHooksResource struct {
+	client *client.OryClient
+}
+
+type FlowHooksResourceModel struct {
+	ID         types.String    `tfsdk:"id"`
+	ProjectID  types.String    `tfsdk:"project_id"`
+	Flow       types.String    `tfsdk:"flow"`
+	Timing     types.String    `tfsdk:"timing"`
+	AuthMethod types.String    `tfsdk:"auth_method"`
+	Hooks      []FlowHookModel `tfsdk:"hooks"`
+}
+
+// FlowHookModel is one entry of the ordered hooks list.
+type FlowHookModel struct {
+	Hook   types.String           `tfsdk:"hook"`
+	Config helpers.NormalizedJSON `tfsdk:"config"`
+}
+
+func (r *FlowHooksResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_hooks"
+}
+
+func (r *FlowHooksResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete, ordered list of hooks (webhooks and built-in hooks) for an identity flow, timing and authentication method.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID in the format project_id:flow:timing:auth_method, or project_id:flow:before for before hooks.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Project ID. If not set, uses provider's project_id.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"flow": schema.StringAttribute{
+				Description: "Identity flow (login, registration, recovery, settings, verification).",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("login", "registration", "recovery", "settings", "verification"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timing": schema.StringAttribute{
+				Description: "When the hooks run: 'before' or 'after' the flow.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(timingBefore, timingAfter),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auth_method": schema.StringAttribute{
+				Description: "Authentication method the hooks run after (password, oidc, code, webauthn, passkey, totp, lookup_secret). Defaults to 'password'. Only used for 'after' timing.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("password"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("password", "oidc", "code", "webauthn", "passkey", "totp", "lookup_secret"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hooks": schema.ListNestedAttribute{
+				Description: "The hooks in the order they run. Hooks not listed here are removed.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hook": schema.StringAttribute{
+							Description: "Hook type: web_hook, session, show_verification_ui, revoke_active_sessions, require_verified_address or b2b_sso.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("web_hook", "session", "show_verification_ui", "revoke_active_sessions", "require_verified_address", "b2b_sso"),
+							},
+						},
+						"config": schema.StringAttribute{
+							Description: "Hook configuration as JSON string. For web_hook: url, method, body (Jsonnet, encoded automatically), response, can_interrupt and auth.",
+							CustomType:  helpers.NormalizedJSONType{},
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *FlowHooksResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	r.client = oryClient
+}
+
+func hookPath(flow, timing, authMethod string) string {
+	if timing == timingAfter {
+		return fmt.Sprintf("/services/identity/config/selfservice/flows/%s/%s/%s/hooks", flow, timing, authMethod)
+	}
+	return fmt.Sprintf("/services/identity/config/selfservice/flows/%s/%s/hooks", flow, timing)
+}
+
+func flowHooksID(projectID, flow, timing, authMethod string) string {
+	if timing == timingAfter {
+		return fmt.Sprintf("%s:%s:%s:%s", projectID, flow, timing, authMethod)
+	}
+	return fmt.Sprintf("%s:%s:%s", projectID, flow, timing)
+}
+
+func hooksFromProject(project *ory.Project, flow, timing, authMethod string) []map[string]interface{} {
+	if project.Services.Identity == nil {
+		return []map[string]interface{}{}
+	}
+
+	selfservice, _ := project.Services.Identity.Config["selfservice"].(map[string]interface{})
+	flows, _ := selfservice["flows"].(map[string]interface{})
+	flowConfig, _ := flows[flow].(map[string]interface{})
+	timingConfig, _ := flowConfig[timing].(map[string]interface{})
+
+	// For 'after' timing, hooks are nested under the auth method
+	if timing == timingAfter {
+		timingConfig, _ = timingConfig[authMethod].(map[string]interface{})
+	}
+	hooks, _ := timingConfig["hooks"].([]interface{})
+
+	result := make([]map[string]interface{}, 0, len(hooks))
+	for _, h := range hooks {
+		if hm, ok := h.(map[string]interface{}); ok {
+			result = append(result, hm)
+		}
+	}
+	return result
+}
+
+// buildHooksValue converts the planned hooks to the API format. Plain
+// webhook bodies are base64-encoded, like ory_action does.
+func buildHooksValue(hooks []FlowHookModel) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(hooks))
+	for i, h := range hooks {
+		value := map[string]interface{}{"hook": h.Hook.ValueString()}
+		if !h.Config.IsNull() && !h.Config.IsUnknown() {
+			var config map[string]interface{}
+			if err := h.Config.Unmarshal(&config); err != nil {
+				return nil, fmt.Errorf("hooks[%d].config must be a JSON object: %w", i, err)
+			}
+			if body, ok := config["body"].(string); ok && body != "" && !isBodyReference(body) {
+				config["body"] = "base64://" + base64.StdEncoding.EncodeToString([]byte(body))
+			}
+			value["config"] = config
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func isBodyReference(body string) bool {
+	for _, prefix := range []string{"base64://", "http://", "https://", "file://"} {
+		if strings.HasPrefix(body, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readHooks converts the API hooks to the model. Values the API does not
+// return verbatim are taken from the prior hook at the same position when it
+// is the same hook type: webhook bodies stored as URLs, bodies the API
+// base64-encoded, and auth secrets.
+func readHooks(apiHooks []map[string]interface{}, prior []FlowHookModel) ([]FlowHookModel, error) {
+	result := make([]FlowHookModel, 0, len(apiHooks))
+	for i, h := range apiHooks {
+		hook, _ := h["hook"].(string)
+		model := FlowHookModel{Hook: types.StringValue(hook), Config: helpers.NewNormalizedJSONNull()}
+
+		var priorConfig map[string]interface{}
+		priorIsNull := true
+		if i < len(prior) && prior[i].Hook.ValueString() == hook && !prior[i].Config.IsNull() && !prior[i].Config.IsUnknown() {
+			priorIsNull = false
+			_ = prior[i].Config.Unmarshal(&priorConfig)
+		}
+
+		config, _ := h["config"].(map[string]interface{})
+		if len(config) == 0 && priorIsNull {
+			result = append(result, model)
+			continue
+		}
+		if config == nil {
+			config = map[string]interface{}{}
+		}
+
+		if body, ok := config["body"].(string); ok {
+			priorBody, _ := priorConfig["body"].(string)
+			switch {
+			case priorBody == body:
+			case strings.HasPrefix(body, "base64://"):
+				if decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(body, "base64://")); err == nil {
+					config["body"] = string(decoded)
+				}
+			case (strings.HasPrefix(body, "http://") || strings.HasPrefix(body, "https://")) && priorBody != "":
+				// The API stored the body and returns a URL to it.
+				config["body"] = priorBody
+			}
+		}
+
+		if auth, ok := config["auth"].(map[string]interface{}); ok {
+			priorAuth, _ := priorConfig["auth"].(map[string]interface{})
+			if reflect.DeepEqual(auth["type"], priorAuth["type"]) {
+				authConfig, _ := auth["config"].(map[string]interface{})
+				priorAuthConfig, _ := priorAuth["config"].(map[string]interface{})
+				for _, secret := range []string{"password", "value"} {
+					if v, ok := priorAuthConfig[secret]; ok && authConfig != nil {
+						authConfig[secret] = v
+					}
+				}
+			}
+		}
+
+		configJSON, err := helpers.NewNormalizedJSONFromObject(config)
+		if err != nil {
+			return nil, fmt.Errorf("encoding config of hook %d: %w", i, err)
+		}
+		model.Config = configJSON
+		result = append(result, model)
+	}
+	return result, nil
+}
+
+func (r *FlowHooksResource) putHooks(ctx context.Context, plan *FlowHooksResourceModel) ([]map[string]interface{}, error) {
+	hooksValue, err := buildHooksValue(plan.Hooks)
+	if err != nil {
+		return nil, err
+	}
+
+	flow, timing, authMethod := plan.Flow.ValueString(), plan.Timing.ValueString(), plan.AuthMethod.ValueString()
+	patches := []ory.JsonPatch{{
+		Op:    "replace",
+		Path:  hookPath(flow, timing, authMethod),
+		Value: hooksValue,
+	}}
+
+	result, err := r.client.PatchProject(ctx, plan.ProjectID.ValueString(), patches)
+	if err != nil {
+		return nil, err
+	}
+	project := result.GetProject()
+	return hooksFromProject(&project, flow, timing, authMethod), nil
+}
+
+func (r *FlowHooksResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FlowHooksResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ProjectID = types.StringValue(projectID)
+
+	if _, err := r.putHooks(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("Error Creating Flow Hooks", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(flowHooksID(projectID, plan.Flow.ValueString(), plan.Timing.ValueString(), plan.AuthMethod.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *FlowHooksResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FlowHooksResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := state.ProjectID.ValueString()
+	project := r.client.GetCachedProject(projectID)
+	if project == nil {
+		var err error
+		project, err = r.client.GetProject(ctx, projectID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Flow Hooks", err.Error())
+			return
+		}
+	}
+
+	apiHooks := hooksFromProject(project, state.Flow.ValueString(), state.Timing.ValueString(), state.AuthMethod.ValueString())
+	hooks, err := readHooks(apiHooks, state.Hooks)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Flow Hooks", err.Error())
+		return
+	}
+	state.Hooks = hooks
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *FlowHooksResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FlowHooksResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ProjectID = types.StringValue(projectID)
+
+	if _, err := r.putHooks(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("Error Updating Flow Hooks", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(flowHooksID(projectID, plan.Flow.ValueString(), plan.Timing.ValueString(), plan.AuthMethod.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *FlowHooksResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FlowHooksResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The resource owns the list, so destroying it leaves no hooks.
+	state.Hooks = []FlowHookModel{}
+	if _, err := r.putHooks(ctx, &state); err != nil {
+		resp.Diagnostics.AddError("Error Deleting Flow Hooks", err.Error())
+		return
+	}
+}
+
+func (r *FlowHooksResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+
+	var projectID, flow, timing, authMethod string
+	switch {
+	case len(parts) == 3 && parts[2] == timingBefore:
+		projectID, flow, timing, authMethod = parts[0], parts[1], parts[2], "password"
+	case len(parts) == 4 && parts[2] == timingAfter:
+		projectID, flow, timing, authMethod = parts[0], parts[1], parts[2], parts[3]
+	default:
+		resp.Diagnostics.AddError("Invalid Import ID",
+			"Import ID must be in one of these formats:\n"+
+				"  - For 'after' timing: project_id:flow:after:auth_method\n"+
+				"  - For 'before' timing: project_id:flow:before")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), flowHooksID(projectID, flow, timing, authMethod))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("flow"), flow)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("timing"), timing)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("auth_method"), authMethod)...)
+}
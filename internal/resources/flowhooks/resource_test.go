@@ -0,0 +1,58 @@
+//go:build acceptance
+
+package flowhooks_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccFlowHooksResource_basic(t *testing.T) {
+	vars := map[string]string{
+		"WebhookURL": "https://example.com/flow-hooks-test",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", vars),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_flow_hooks.test", "id"),
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "flow", "login"),
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "timing", "after"),
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "hooks.#", "2"),
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "hooks.0.hook", "web_hook"),
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "hooks.1.hook", "revoke_active_sessions"),
+				),
+			},
+			// Import using project_id:flow:timing:auth_method
+			{
+				ResourceName:            "ory_flow_hooks.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"hooks.0.config"},
+			},
+			// Reorder in place, without a window in which the flow has no hooks
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/reordered.tf.tmpl", vars),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ory_flow_hooks.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "hooks.#", "2"),
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "hooks.0.hook", "revoke_active_sessions"),
+					resource.TestCheckResourceAttr("ory_flow_hooks.test", "hooks.1.hook", "web_hook"),
+				),
+			},
+		},
+	})
+}
@@ -2,17 +2,22 @@ package identity
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
@@ -21,9 +26,11 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &IdentityResource{}
-	_ resource.ResourceWithConfigure   = &IdentityResource{}
-	_ resource.ResourceWithImportState = &IdentityResource{}
+	_ resource.Resource                   = &IdentityResource{}
+	_ resource.ResourceWithConfigure      = &IdentityResource{}
+	_ resource.ResourceWithImportState    = &IdentityResource{}
+	_ resource.ResourceWithValidateConfig = &IdentityResource{}
+	_ resource.ResourceWithModifyPlan     = &IdentityResource{}
 )
 
 // NewResource returns a new Identity resource.
@@ -38,13 +45,48 @@ type IdentityResource struct {
 
 // IdentityResourceModel describes the resource data model.
 type IdentityResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	SchemaID       types.String `tfsdk:"schema_id"`
-	Traits         types.String `tfsdk:"traits"`
-	State          types.String `tfsdk:"state"`
-	Password       types.String `tfsdk:"password"`
-	MetadataPublic types.String `tfsdk:"metadata_public"`
-	MetadataAdmin  types.String `tfsdk:"metadata_admin"`
+	ID             types.String           `tfsdk:"id"`
+	SchemaID       types.String           `tfsdk:"schema_id"`
+	Traits         helpers.NormalizedJSON `tfsdk:"traits"`
+	State          types.String           `tfsdk:"state"`
+	Password       types.String           `tfsdk:"password"`
+	MetadataPublic helpers.NormalizedJSON `tfsdk:"metadata_public"`
+	MetadataAdmin  helpers.NormalizedJSON `tfsdk:"metadata_admin"`
+	ExternalID     types.String           `tfsdk:"external_id"`
+	OrganizationID types.String           `tfsdk:"organization_id"`
+	Credentials    *CredentialsModel      `tfsdk:"credentials"`
+
+	RevokeSessionsOnDeactivate types.Bool `tfsdk:"revoke_sessions_on_deactivate"`
+	RevokeSessionsOnDestroy    types.Bool `tfsdk:"revoke_sessions_on_destroy"`
+
+	VerifiableAddresses []VerifiableAddressModel `tfsdk:"verifiable_addresses"`
+	RecoveryAddresses   []RecoveryAddressModel   `tfsdk:"recovery_addresses"`
+}
+
+// CredentialsModel describes credentials imported into the identity.
+type CredentialsModel struct {
+	HashedPassword types.String          `tfsdk:"hashed_password"`
+	OIDC           []OIDCCredentialModel `tfsdk:"oidc"`
+}
+
+// VerifiableAddressModel describes an address that can be verified.
+type VerifiableAddressModel struct {
+	Value    types.String `tfsdk:"value"`
+	Via      types.String `tfsdk:"via"`
+	Verified types.Bool   `tfsdk:"verified"`
+	Status   types.String `tfsdk:"status"`
+}
+
+// RecoveryAddressModel describes an address that can be used for recovery.
+type RecoveryAddressModel struct {
+	Value types.String `tfsdk:"value"`
+	Via   types.String `tfsdk:"via"`
+}
+
+// OIDCCredentialModel links the identity to a social sign-in provider.
+type OIDCCredentialModel struct {
+	Provider types.String `tfsdk:"provider"`
+	Subject  types.String `tfsdk:"subject"`
 }
 
 func (r *IdentityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -112,6 +154,25 @@ resource "ory_identity" "user_with_password" {
   })
 }
 
+# Identity migrated from another system with a password hash and a linked Google account
+resource "ory_identity" "migrated" {
+  schema_id = "preset://email"
+
+  traits = jsonencode({
+    email = "migrated@example.com"
+  })
+
+  credentials = {
+    hashed_password = var.bcrypt_hash  # e.g. "$2a$10$..."
+    oidc = [
+      {
+        provider = ory_social_provider.google.provider_id
+        subject  = "108251234567890123456"
+      },
+    ]
+  }
+}
+
 # Identity using a custom Terraform-managed schema
 resource "ory_identity" "customer" {
   schema_id = ory_identity_schema.customer.schema_id
@@ -149,11 +210,16 @@ func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"schema_id": schema.StringAttribute{
-				Description: "Identity schema ID. Must match a schema configured in your project (e.g., 'preset://email', a custom schema ID). Check your project's identity schemas in the Ory Console or API.",
-				Required:    true,
+				Description: "Identity schema ID. Must match a schema configured in your project (e.g., 'preset://email', a custom schema ID). Check your project's identity schemas in the Ory Console or API. Defaults to the project's default schema.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"traits": schema.StringAttribute{
 				Description: "Identity traits as JSON string. The structure depends on your identity schema.",
+				CustomType:  helpers.NormalizedJSONType{},
 				Required:    true,
 			},
 			"state": schema.StringAttribute{
@@ -172,13 +238,135 @@ func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaReques
 			},
 			"metadata_public": schema.StringAttribute{
 				Description: "Public metadata as JSON string. Visible to the identity.",
+				CustomType:  helpers.NormalizedJSONType{},
 				Optional:    true,
 			},
 			"metadata_admin": schema.StringAttribute{
 				Description: "Admin metadata as JSON string. Only visible to admins.",
+				CustomType:  helpers.NormalizedJSONType{},
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"external_id": schema.StringAttribute{
+				Description: "External ID of the identity, e.g. its ID in a legacy user store. Must be unique across all identities.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "ID of the organization the identity belongs to (B2B).",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"revoke_sessions_on_deactivate": schema.BoolAttribute{
+				Description: "Revoke all sessions of the identity when its state changes to inactive, so the user is logged out immediately (default: false).",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"revoke_sessions_on_destroy": schema.BoolAttribute{
+				Description: "Revoke all sessions of the identity before deleting it (default: false).",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"credentials": schema.SingleNestedAttribute{
+				Description: "Credentials to import into the identity, e.g. when migrating users from another system. Write-only, not returned on read.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"hashed_password": schema.StringAttribute{
+						Description: "Password hash in a format Ory accepts for import (bcrypt, argon2, pbkdf2, scrypt, firebase scrypt, salted SHA, SSHA, MD5, MD5/SHA crypt, or HMAC). Mutually exclusive with password.",
+						Optional:    true,
+						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRoot("password")),
+						},
+					},
+					"oidc": schema.ListNestedAttribute{
+						Description: "Social sign-in (OIDC) accounts to link to the identity.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"provider": schema.StringAttribute{
+									Description: "The social sign-in provider ID (e.g., 'google', or ory_social_provider.<name>.provider_id).",
+									Required:    true,
+								},
+								"subject": schema.StringAttribute{
+									Description: "The subject (sub claim) of the user at the provider.",
+									Required:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"verifiable_addresses": schema.ListNestedAttribute{
+				Description: "Verifiable addresses of the identity and their verification status. When set, the addresses are read back and changes made outside Terraform (e.g. the user verifying an address) are shown as drift. Each address must also be present in the traits as defined by the identity schema.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Description: "The address, e.g. an email address or phone number.",
+							Required:    true,
+						},
+						"via": schema.StringAttribute{
+							Description: "The delivery method: email or sms (default: email).",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("email"),
+							Validators: []validator.String{
+								stringvalidator.OneOf("email", "sms"),
+							},
+						},
+						"verified": schema.BoolAttribute{
+							Description: "Whether the address is verified (default: false).",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"status": schema.StringAttribute{
+							Description: "Verification status: pending, sent, or completed. Defaults to completed for verified addresses and pending otherwise.",
+							Optional:    true,
+							Computed:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("pending", "sent", "completed"),
+							},
+						},
+					},
+				},
+			},
+			"recovery_addresses": schema.ListNestedAttribute{
+				Description: "Addresses that can be used to recover the identity. When set, the addresses are read back and changes made outside Terraform are shown as drift. Each address must also be present in the traits as defined by the identity schema.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Description: "The address, e.g. an email address or phone number.",
+							Required:    true,
+						},
+						"via": schema.StringAttribute{
+							Description: "The delivery method: email or sms (default: email).",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("email"),
+							Validators: []validator.String{
+								stringvalidator.OneOf("email", "sms"),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -200,6 +388,199 @@ func (r *IdentityResource) Configure(ctx context.Context, req resource.Configure
 	r.client = oryClient
 }
 
+func (r *IdentityResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var hashedPassword types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("credentials").AtName("hashed_password"), &hashedPassword)...)
+	if resp.Diagnostics.HasError() || hashedPassword.IsNull() || hashedPassword.IsUnknown() {
+		return
+	}
+
+	if err := helpers.ValidateHashedPassword(hashedPassword.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials").AtName("hashed_password"),
+			"Invalid Password Hash",
+			err.Error(),
+		)
+	}
+}
+
+// ModifyPlan validates the traits against the identity schema, so schema
+// violations such as malformed emails or missing required traits are
+// reported during plan instead of apply. Without a schema_id, the project's
+// default schema is used. Validation is skipped when the schema cannot be
+// resolved; the API then validates the traits on apply.
+//
+// It also plans external_id and organization_id as null when they are
+// removed from the configuration, which UseStateForUnknown would otherwise
+// prevent, so that Update clears them.
+func (r *IdentityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		for _, p := range []path.Path{path.Root("external_id"), path.Root("organization_id")} {
+			var configValue, stateValue types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &configValue)...)
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &stateValue)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if configValue.IsNull() && !stateValue.IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, types.StringNull())...)
+			}
+		}
+	}
+
+	if r.client == nil {
+		return
+	}
+
+	var plan IdentityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without a configured schema_id the identity is created with the
+	// project's default schema.
+	var configSchemaID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schema_id"), &configSchemaID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if configSchemaID.IsNull() && plan.SchemaID.IsUnknown() {
+		if defaultSchemaID := r.resolveDefaultSchemaID(ctx); defaultSchemaID != "" {
+			plan.SchemaID = types.StringValue(defaultSchemaID)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_id"), plan.SchemaID)...)
+		}
+	}
+
+	if plan.SchemaID.IsUnknown() || plan.Traits.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state IdentityResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.SchemaID.Equal(plan.SchemaID) && helpers.JSONSemanticallyEqual(state.Traits.ValueString(), plan.Traits.ValueString()) {
+			return
+		}
+	}
+
+	var traits interface{}
+	if err := plan.Traits.Unmarshal(&traits); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("traits"),
+			"Invalid Traits JSON",
+			"Could not parse traits as JSON: "+err.Error(),
+		)
+		return
+	}
+
+	schemaID := plan.SchemaID.ValueString()
+	identitySchema := r.resolveIdentitySchema(ctx, schemaID)
+	if identitySchema == nil {
+		return
+	}
+
+	traitErrors, err := helpers.ValidateTraits(identitySchema, traits)
+	if err != nil {
+		tflog.Debug(ctx, "Skipping plan-time traits validation", map[string]interface{}{
+			"schema_id": schemaID,
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	for _, traitErr := range traitErrors {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("traits"),
+			"Invalid Identity Traits",
+			fmt.Sprintf("%s (identity schema %q)", traitErr.Error(), schemaID),
+		)
+	}
+}
+
+// resolveIdentitySchema returns the identity schema with the given ID, or
+// nil if it cannot be found. Schemas are looked up through the project API
+// first, then in the project configuration, where schemas managed by
+// ory_identity_schema are listed under their Terraform schema_id.
+func (r *IdentityResource) resolveIdentitySchema(ctx context.Context, schemaID string) map[string]interface{} {
+	cfg := r.client.Config()
+
+	if cfg.ProjectSlug != "" && cfg.ProjectAPIKey != "" {
+		schemas, err := r.client.ListIdentitySchemasCached(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "Could not list identity schemas", map[string]interface{}{"error": err.Error()})
+		}
+		for _, s := range schemas {
+			if s.Id == schemaID {
+				return s.Schema
+			}
+		}
+	}
+
+	identityConfig := r.projectIdentityConfig(ctx)
+	schemas, _ := identityConfig["schemas"].([]interface{})
+	for _, s := range schemas {
+		entry, _ := s.(map[string]interface{})
+		if entry["id"] != schemaID {
+			continue
+		}
+		url, _ := entry["url"].(string)
+		if !strings.HasPrefix(url, "base64://") {
+			return nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(url, "base64://"))
+		if err != nil {
+			return nil
+		}
+		var identitySchema map[string]interface{}
+		if err := json.Unmarshal(decoded, &identitySchema); err != nil {
+			return nil
+		}
+		return identitySchema
+	}
+
+	return nil
+}
+
+// resolveDefaultSchemaID returns the default identity schema ID of the
+// project, or "" if it cannot be read.
+func (r *IdentityResource) resolveDefaultSchemaID(ctx context.Context) string {
+	defaultSchemaID, _ := r.projectIdentityConfig(ctx)["default_schema_id"].(string)
+	return defaultSchemaID
+}
+
+// projectIdentityConfig returns the identity section of the project's
+// identity service configuration, or nil if it cannot be read. Reading the
+// project requires a workspace API key.
+func (r *IdentityResource) projectIdentityConfig(ctx context.Context) map[string]interface{} {
+	if r.client.Config().WorkspaceAPIKey == "" || r.client.ProjectID() == "" {
+		return nil
+	}
+
+	project := r.client.GetCachedProject(r.client.ProjectID())
+	if project == nil {
+		var err error
+		project, err = r.client.GetProject(ctx, r.client.ProjectID())
+		if err != nil {
+			tflog.Debug(ctx, "Could not read project identity config", map[string]interface{}{"error": err.Error()})
+			return nil
+		}
+	}
+	if project.Services.Identity == nil {
+		return nil
+	}
+
+	identityConfig, _ := project.Services.Identity.Config["identity"].(map[string]interface{})
+	return identityConfig
+}
+
 func (r *IdentityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan IdentityResourceModel
 
@@ -214,7 +595,7 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	var traits map[string]interface{}
-	if err := json.Unmarshal([]byte(plan.Traits.ValueString()), &traits); err != nil {
+	if err := plan.Traits.Unmarshal(&traits); err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid Traits JSON",
 			"Could not parse traits as JSON: "+err.Error(),
@@ -228,19 +609,20 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 		State:    ory.PtrString(plan.State.ValueString()),
 	}
 
-	if !plan.Password.IsNull() && !plan.Password.IsUnknown() {
-		body.Credentials = &ory.IdentityWithCredentials{
-			Password: &ory.IdentityWithCredentialsPassword{
-				Config: &ory.IdentityWithCredentialsPasswordConfig{
-					Password: ory.PtrString(plan.Password.ValueString()),
-				},
-			},
-		}
+	if !plan.ExternalID.IsNull() && !plan.ExternalID.IsUnknown() {
+		body.ExternalId = ory.PtrString(plan.ExternalID.ValueString())
+	}
+	if !plan.OrganizationID.IsNull() && !plan.OrganizationID.IsUnknown() {
+		body.OrganizationId = *ory.NewNullableString(ory.PtrString(plan.OrganizationID.ValueString()))
 	}
 
+	body.Credentials = buildCredentials(plan.Password, plan.Credentials)
+	body.VerifiableAddresses = buildVerifiableAddresses(plan.VerifiableAddresses)
+	body.RecoveryAddresses = buildRecoveryAddresses(plan.RecoveryAddresses)
+
 	if !plan.MetadataPublic.IsNull() && !plan.MetadataPublic.IsUnknown() {
 		var metadataPublic interface{}
-		if err := json.Unmarshal([]byte(plan.MetadataPublic.ValueString()), &metadataPublic); err != nil {
+		if err := plan.MetadataPublic.Unmarshal(&metadataPublic); err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Metadata Public JSON",
 				"Could not parse metadata_public as JSON: "+err.Error(),
@@ -252,7 +634,7 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 
 	if !plan.MetadataAdmin.IsNull() && !plan.MetadataAdmin.IsUnknown() {
 		var metadataAdmin interface{}
-		if err := json.Unmarshal([]byte(plan.MetadataAdmin.ValueString()), &metadataAdmin); err != nil {
+		if err := plan.MetadataAdmin.Unmarshal(&metadataAdmin); err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Metadata Admin JSON",
 				"Could not parse metadata_admin as JSON: "+err.Error(),
@@ -274,11 +656,13 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 	plan.ID = types.StringValue(identity.GetId())
 	plan.SchemaID = types.StringValue(identity.GetSchemaId())
 	plan.State = types.StringValue(identity.GetState())
+	setAddresses(&plan, identity)
+	setIdentityReferences(&plan, identity)
 
 	if identity.Traits != nil {
-		traitsJSON, err := json.Marshal(identity.Traits)
+		traitsJSON, err := helpers.NewNormalizedJSONFromObject(identity.Traits)
 		if err == nil {
-			plan.Traits = types.StringValue(string(traitsJSON))
+			plan.Traits = traitsJSON
 		}
 	}
 
@@ -296,8 +680,7 @@ func (r *IdentityResource) Read(ctx context.Context, req resource.ReadRequest, r
 	identity, err := r.client.GetIdentity(ctx, state.ID.ValueString())
 	if err != nil {
 		// Check if it's a 404 (identity deleted outside Terraform)
-		errStr := err.Error()
-		if strings.Contains(errStr, "404") || strings.Contains(strings.ToLower(errStr), "not found") {
+		if isNotFound(err) {
 			// Identity was deleted outside Terraform, remove from state
 			resp.Diagnostics.AddWarning(
 				"Identity Not Found",
@@ -317,26 +700,38 @@ func (r *IdentityResource) Read(ctx context.Context, req resource.ReadRequest, r
 	state.State = types.StringValue(identity.GetState())
 
 	if identity.Traits != nil {
-		traitsJSON, err := json.Marshal(identity.Traits)
+		traitsJSON, err := helpers.NewNormalizedJSONFromObject(identity.Traits)
 		if err == nil {
-			state.Traits = types.StringValue(string(traitsJSON))
+			state.Traits = traitsJSON
 		}
 	}
 
 	if identity.MetadataPublic != nil {
-		metadataJSON, err := json.Marshal(identity.MetadataPublic)
+		metadataJSON, err := helpers.NewNormalizedJSONFromObject(identity.MetadataPublic)
 		if err == nil {
-			state.MetadataPublic = types.StringValue(string(metadataJSON))
+			state.MetadataPublic = metadataJSON
 		}
 	}
 
 	if identity.MetadataAdmin != nil {
-		metadataJSON, err := json.Marshal(identity.MetadataAdmin)
+		metadataJSON, err := helpers.NewNormalizedJSONFromObject(identity.MetadataAdmin)
 		if err == nil {
-			state.MetadataAdmin = types.StringValue(string(metadataJSON))
+			state.MetadataAdmin = metadataJSON
 		}
 	}
 
+	// The revocation options only exist in Terraform; default them after
+	// import.
+	if state.RevokeSessionsOnDeactivate.IsNull() {
+		state.RevokeSessionsOnDeactivate = types.BoolValue(false)
+	}
+	if state.RevokeSessionsOnDestroy.IsNull() {
+		state.RevokeSessionsOnDestroy = types.BoolValue(false)
+	}
+
+	setAddresses(&state, identity)
+	setIdentityReferences(&state, identity)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -351,7 +746,7 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	var traits map[string]interface{}
-	if err := json.Unmarshal([]byte(plan.Traits.ValueString()), &traits); err != nil {
+	if err := plan.Traits.Unmarshal(&traits); err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid Traits JSON",
 			"Could not parse traits as JSON: "+err.Error(),
@@ -365,9 +760,13 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 		State:    plan.State.ValueString(),
 	}
 
+	if !plan.ExternalID.IsNull() && !plan.ExternalID.IsUnknown() {
+		body.ExternalId = ory.PtrString(plan.ExternalID.ValueString())
+	}
+
 	if !plan.MetadataPublic.IsNull() && !plan.MetadataPublic.IsUnknown() {
 		var metadataPublic interface{}
-		if err := json.Unmarshal([]byte(plan.MetadataPublic.ValueString()), &metadataPublic); err != nil {
+		if err := plan.MetadataPublic.Unmarshal(&metadataPublic); err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Metadata Public JSON",
 				"Could not parse metadata_public as JSON: "+err.Error(),
@@ -379,7 +778,7 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 
 	if !plan.MetadataAdmin.IsNull() && !plan.MetadataAdmin.IsUnknown() {
 		var metadataAdmin interface{}
-		if err := json.Unmarshal([]byte(plan.MetadataAdmin.ValueString()), &metadataAdmin); err != nil {
+		if err := plan.MetadataAdmin.Unmarshal(&metadataAdmin); err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Metadata Admin JSON",
 				"Could not parse metadata_admin as JSON: "+err.Error(),
@@ -389,6 +788,12 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 		body.MetadataAdmin = metadataAdmin
 	}
 
+	// Only send credentials when they change; importing replaces the
+	// identity's existing credentials of the same type.
+	if !credentialsEqual(plan.Credentials, state.Credentials) && plan.Credentials != nil {
+		body.Credentials = buildCredentials(types.StringNull(), plan.Credentials)
+	}
+
 	identity, err := r.client.UpdateIdentity(ctx, state.ID.ValueString(), body)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -398,14 +803,49 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// The update body cannot carry addresses or the organization, or
+	// clear the external ID, and updating the traits recomputes the
+	// addresses, so apply them with a patch.
+	patches := addressPatches(plan)
+	if !plan.OrganizationID.IsNull() && !plan.OrganizationID.IsUnknown() && plan.OrganizationID.ValueString() != identity.GetOrganizationId() {
+		patches = append(patches, ory.JsonPatch{Op: "replace", Path: "/organization_id", Value: plan.OrganizationID.ValueString()})
+	}
+	if plan.OrganizationID.IsNull() && identity.GetOrganizationId() != "" {
+		patches = append(patches, ory.JsonPatch{Op: "remove", Path: "/organization_id"})
+	}
+	if plan.ExternalID.IsNull() && identity.GetExternalId() != "" {
+		patches = append(patches, ory.JsonPatch{Op: "remove", Path: "/external_id"})
+	}
+	if len(patches) > 0 {
+		identity, err = r.client.PatchIdentity(ctx, state.ID.ValueString(), patches)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Identity",
+				"Could not patch identity addresses, organization, or external ID: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if plan.RevokeSessionsOnDeactivate.ValueBool() && identity.GetState() == "inactive" && state.State.ValueString() != "inactive" {
+		if err := r.client.DeleteIdentitySessions(ctx, state.ID.ValueString()); err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error Revoking Identity Sessions",
+				"The identity was deactivated, but its sessions could not be revoked: "+err.Error(),
+			)
+		}
+	}
+
 	plan.ID = state.ID
 	plan.SchemaID = types.StringValue(identity.GetSchemaId())
 	plan.State = types.StringValue(identity.GetState())
+	setAddresses(&plan, identity)
+	setIdentityReferences(&plan, identity)
 
 	if identity.Traits != nil {
-		traitsJSON, err := json.Marshal(identity.Traits)
+		traitsJSON, err := helpers.NewNormalizedJSONFromObject(identity.Traits)
 		if err == nil {
-			plan.Traits = types.StringValue(string(traitsJSON))
+			plan.Traits = traitsJSON
 		}
 	}
 
@@ -420,6 +860,16 @@ func (r *IdentityResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if state.RevokeSessionsOnDestroy.ValueBool() {
+		if err := r.client.DeleteIdentitySessions(ctx, state.ID.ValueString()); err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error Revoking Identity Sessions",
+				"Could not revoke sessions before deleting the identity: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	err := r.client.DeleteIdentity(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -430,6 +880,235 @@ func (r *IdentityResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// ImportState accepts an identity ID, or external_id:<value> to import by
+// the identity's external ID.
 func (r *IdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	externalID, ok := strings.CutPrefix(req.ID, "external_id:")
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if externalID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected external_id:<value> with a non-empty external ID.",
+		)
+		return
+	}
+
+	identity, err := r.client.GetIdentityByExternalID(ctx, externalID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Identity",
+			fmt.Sprintf("Could not find identity with external ID %q: %s", externalID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.GetId())...)
+}
+
+// isNotFound reports whether an API error is a 404.
+func isNotFound(err error) bool {
+	errStr := err.Error()
+	return strings.Contains(errStr, "404") || strings.Contains(strings.ToLower(errStr), "not found")
+}
+
+// setIdentityReferences copies the external and organization IDs into the
+// model.
+func setIdentityReferences(model *IdentityResourceModel, identity *ory.Identity) {
+	model.ExternalID = types.StringNull()
+	if identity.ExternalId != nil && *identity.ExternalId != "" {
+		model.ExternalID = types.StringValue(*identity.ExternalId)
+	}
+
+	model.OrganizationID = types.StringNull()
+	if org := identity.GetOrganizationId(); org != "" {
+		model.OrganizationID = types.StringValue(org)
+	}
+}
+
+// buildCredentials returns the credentials to import, or nil if there are none.
+func buildCredentials(password types.String, creds *CredentialsModel) *ory.IdentityWithCredentials {
+	result := &ory.IdentityWithCredentials{}
+
+	passwordConfig := &ory.IdentityWithCredentialsPasswordConfig{}
+	if !password.IsNull() && !password.IsUnknown() {
+		passwordConfig.Password = ory.PtrString(password.ValueString())
+	}
+	if creds != nil && !creds.HashedPassword.IsNull() && !creds.HashedPassword.IsUnknown() {
+		passwordConfig.HashedPassword = ory.PtrString(creds.HashedPassword.ValueString())
+	}
+	if passwordConfig.Password != nil || passwordConfig.HashedPassword != nil {
+		result.Password = &ory.IdentityWithCredentialsPassword{Config: passwordConfig}
+	}
+
+	if creds != nil && len(creds.OIDC) > 0 {
+		providers := make([]ory.IdentityWithCredentialsOidcConfigProvider, 0, len(creds.OIDC))
+		for _, o := range creds.OIDC {
+			providers = append(providers, ory.IdentityWithCredentialsOidcConfigProvider{
+				Provider: o.Provider.ValueString(),
+				Subject:  o.Subject.ValueString(),
+			})
+		}
+		result.Oidc = &ory.IdentityWithCredentialsOidc{
+			Config: &ory.IdentityWithCredentialsOidcConfig{Providers: providers},
+		}
+	}
+
+	if result.Password == nil && result.Oidc == nil {
+		return nil
+	}
+	return result
+}
+
+// credentialsEqual reports whether two credentials configurations are the same.
+func credentialsEqual(a, b *CredentialsModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !a.HashedPassword.Equal(b.HashedPassword) || len(a.OIDC) != len(b.OIDC) {
+		return false
+	}
+	for i := range a.OIDC {
+		if !a.OIDC[i].Provider.Equal(b.OIDC[i].Provider) || !a.OIDC[i].Subject.Equal(b.OIDC[i].Subject) {
+			return false
+		}
+	}
+	return true
+}
+
+// verifiableAddressStatus returns the configured status, or the status
+// implied by the verified flag when none is configured.
+func verifiableAddressStatus(a VerifiableAddressModel) string {
+	if !a.Status.IsNull() && !a.Status.IsUnknown() {
+		return a.Status.ValueString()
+	}
+	if a.Verified.ValueBool() {
+		return "completed"
+	}
+	return "pending"
+}
+
+func buildVerifiableAddresses(addresses []VerifiableAddressModel) []ory.VerifiableIdentityAddress {
+	if addresses == nil {
+		return nil
+	}
+	result := make([]ory.VerifiableIdentityAddress, 0, len(addresses))
+	for _, a := range addresses {
+		result = append(result, ory.VerifiableIdentityAddress{
+			Value:    a.Value.ValueString(),
+			Via:      a.Via.ValueString(),
+			Verified: a.Verified.ValueBool(),
+			Status:   verifiableAddressStatus(a),
+		})
+	}
+	return result
+}
+
+func buildRecoveryAddresses(addresses []RecoveryAddressModel) []ory.RecoveryIdentityAddress {
+	if addresses == nil {
+		return nil
+	}
+	result := make([]ory.RecoveryIdentityAddress, 0, len(addresses))
+	for _, a := range addresses {
+		result = append(result, ory.RecoveryIdentityAddress{
+			Value: a.Value.ValueString(),
+			Via:   a.Via.ValueString(),
+		})
+	}
+	return result
+}
+
+// addressPatches returns JSON patches replacing the identity's addresses
+// with the configured ones. Unconfigured address lists are left alone.
+func addressPatches(plan IdentityResourceModel) []ory.JsonPatch {
+	var patches []ory.JsonPatch
+	if plan.VerifiableAddresses != nil {
+		patches = append(patches, ory.JsonPatch{
+			Op:    "replace",
+			Path:  "/verifiable_addresses",
+			Value: buildVerifiableAddresses(plan.VerifiableAddresses),
+		})
+	}
+	if plan.RecoveryAddresses != nil {
+		patches = append(patches, ory.JsonPatch{
+			Op:    "replace",
+			Path:  "/recovery_addresses",
+			Value: buildRecoveryAddresses(plan.RecoveryAddresses),
+		})
+	}
+	return patches
+}
+
+// setAddresses copies the identity's addresses into the model for the
+// address lists that are managed (non-null). Addresses keep the order of
+// the model, so reordering by the API is not reported as drift; addresses
+// that were added outside Terraform are appended. Matched addresses keep
+// the configured spelling, as Kratos lowercases email addresses.
+func setAddresses(model *IdentityResourceModel, identity *ory.Identity) {
+	if model.VerifiableAddresses != nil {
+		byValue := make(map[string]ory.VerifiableIdentityAddress, len(identity.VerifiableAddresses))
+		for _, a := range identity.VerifiableAddresses {
+			byValue[addressKey(a.Via, a.Value)] = a
+		}
+
+		result := make([]VerifiableAddressModel, 0, len(identity.VerifiableAddresses))
+		for _, m := range model.VerifiableAddresses {
+			key := addressKey(m.Via.ValueString(), m.Value.ValueString())
+			if a, ok := byValue[key]; ok {
+				address := verifiableAddressModel(a)
+				address.Value = m.Value
+				result = append(result, address)
+				delete(byValue, key)
+			}
+		}
+		for _, a := range identity.VerifiableAddresses {
+			if _, ok := byValue[addressKey(a.Via, a.Value)]; ok {
+				result = append(result, verifiableAddressModel(a))
+			}
+		}
+		model.VerifiableAddresses = result
+	}
+
+	if model.RecoveryAddresses != nil {
+		byValue := make(map[string]ory.RecoveryIdentityAddress, len(identity.RecoveryAddresses))
+		for _, a := range identity.RecoveryAddresses {
+			byValue[addressKey(a.Via, a.Value)] = a
+		}
+
+		result := make([]RecoveryAddressModel, 0, len(identity.RecoveryAddresses))
+		for _, m := range model.RecoveryAddresses {
+			key := addressKey(m.Via.ValueString(), m.Value.ValueString())
+			if a, ok := byValue[key]; ok {
+				result = append(result, RecoveryAddressModel{Value: m.Value, Via: types.StringValue(a.Via)})
+				delete(byValue, key)
+			}
+		}
+		for _, a := range identity.RecoveryAddresses {
+			if _, ok := byValue[addressKey(a.Via, a.Value)]; ok {
+				result = append(result, RecoveryAddressModel{Value: types.StringValue(a.Value), Via: types.StringValue(a.Via)})
+			}
+		}
+		model.RecoveryAddresses = result
+	}
+}
+
+// addressKey returns the key an address is matched on. Email addresses are
+// compared case-insensitively.
+func addressKey(via, value string) string {
+	if via == "email" {
+		value = strings.ToLower(value)
+	}
+	return via + ":" + value
+}
+
+func verifiableAddressModel(a ory.VerifiableIdentityAddress) VerifiableAddressModel {
+	return VerifiableAddressModel{
+		Value:    types.StringValue(a.Value),
+		Via:      types.StringValue(a.Via),
+		Verified: types.BoolValue(a.Verified),
+		Status:   types.StringValue(a.Status),
+	}
 }
@@ -3,6 +3,7 @@
 package identity_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -44,6 +45,28 @@ func TestAccIdentityResource_basic(t *testing.T) {
 	})
 }
 
+func TestAccIdentityResource_defaultSchema(t *testing.T) {
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Without schema_id, the project's default schema is used and
+			// validated at plan time
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/default_schema.tf.tmpl", map[string]string{"Email": "not-an-email"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Identity Traits`),
+			},
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/default_schema.tf.tmpl", map[string]string{"Email": "default-schema@example.com"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_identity.test", "schema_id"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccIdentityResource_withMetadata(t *testing.T) {
 	acctest.RunTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.AccPreCheck(t) },
@@ -61,6 +84,29 @@ func TestAccIdentityResource_withMetadata(t *testing.T) {
 	})
 }
 
+func TestAccIdentityResource_formattedJSON(t *testing.T) {
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// The API returns the documents compacted with sorted keys; the
+			// test framework's post-apply plan check fails on any diff.
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/formatted_json.tf.tmpl", map[string]string{"Username": "test-formatted-json-user"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_identity.test", "id"),
+				),
+			},
+			// Refresh must keep the configured formatting as well
+			{
+				Config:             acctest.LoadTestConfig(t, "testdata/formatted_json.tf.tmpl", map[string]string{"Username": "test-formatted-json-user"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
 func TestAccIdentityResource_inactive(t *testing.T) {
 	acctest.RunTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.AccPreCheck(t) },
@@ -76,3 +122,154 @@ func TestAccIdentityResource_inactive(t *testing.T) {
 		},
 	})
 }
+
+func TestAccIdentityResource_withCredentials(t *testing.T) {
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Invalid hash is rejected at plan time
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_credentials.tf.tmpl", map[string]string{
+					"Username":       "test-credentials-user",
+					"HashedPassword": "$2a$10$tooshort",
+				}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`malformed bcrypt hash`),
+			},
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_credentials.tf.tmpl", map[string]string{
+					"Username":       "test-credentials-user",
+					"HashedPassword": "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_identity.test", "id"),
+					resource.TestCheckResourceAttr("ory_identity.test", "credentials.oidc.#", "1"),
+					resource.TestCheckResourceAttr("ory_identity.test", "credentials.oidc.0.provider", "google"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentityResource_invalidTraits(t *testing.T) {
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Traits that violate the identity schema are rejected at plan time
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_traits.tf.tmpl", nil),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`traits\.username: expected string`),
+			},
+		},
+	})
+}
+
+func TestAccIdentityResource_withAddresses(t *testing.T) {
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Create with a verified address
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_addresses.tf.tmpl", map[string]string{
+					"Email":    "tf-addresses@example.com",
+					"Verified": "true",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.#", "1"),
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.0.verified", "true"),
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.0.status", "completed"),
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.0.via", "email"),
+					resource.TestCheckResourceAttr("ory_identity.test", "recovery_addresses.#", "1"),
+				),
+			},
+			// Unverify the address
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_addresses.tf.tmpl", map[string]string{
+					"Email":    "tf-addresses@example.com",
+					"Verified": "false",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.0.verified", "false"),
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.0.status", "pending"),
+				),
+			},
+			// Mixed-case emails match the lowercased addresses returned by the API
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_addresses.tf.tmpl", map[string]string{
+					"Email":    "TF-Addresses@Example.com",
+					"Verified": "false",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.#", "1"),
+					resource.TestCheckResourceAttr("ory_identity.test", "verifiable_addresses.0.value", "TF-Addresses@Example.com"),
+					resource.TestCheckResourceAttr("ory_identity.test", "recovery_addresses.#", "1"),
+					resource.TestCheckResourceAttr("ory_identity.test", "recovery_addresses.0.value", "TF-Addresses@Example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentityResource_withExternalID(t *testing.T) {
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_external_id.tf.tmpl", map[string]string{"ExternalID": "legacy-user-1"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_identity.test", "id"),
+					resource.TestCheckResourceAttr("ory_identity.test", "external_id", "legacy-user-1"),
+				),
+			},
+			// Import by external ID
+			{
+				ResourceName:      "ory_identity.test",
+				ImportState:       true,
+				ImportStateId:     "external_id:legacy-user-1",
+				ImportStateVerify: true,
+			},
+			// Update the external ID
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/with_external_id.tf.tmpl", map[string]string{"ExternalID": "legacy-user-1-renamed"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity.test", "external_id", "legacy-user-1-renamed"),
+				),
+			},
+			// Removing external_id from the configuration clears it
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"Username": "test-external-id-user"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ory_identity.test", "external_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentityResource_revokeSessions(t *testing.T) {
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/revoke_sessions.tf.tmpl", map[string]string{"State": "active"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity.test", "revoke_sessions_on_deactivate", "true"),
+					resource.TestCheckResourceAttr("ory_identity.test", "revoke_sessions_on_destroy", "true"),
+				),
+			},
+			// Deactivating revokes sessions; the identity has none, which must not fail
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/revoke_sessions.tf.tmpl", map[string]string{"State": "inactive"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity.test", "state", "inactive"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,575 @@
+package identityimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+// maxReportedFailures caps the number of per-record diagnostics so a bad
+// import does not flood the output.
+const maxReportedFailures = 20
+
+var (
+	_ resource.Resource               = &IdentityImportResource{}
+	_ resource.ResourceWithConfigure  = &IdentityImportResource{}
+	_ resource.ResourceWithModifyPlan = &IdentityImportResource{}
+)
+
+// NewResource returns a new Identity Import resource.
+func NewResource() resource.Resource {
+	return &IdentityImportResource{}
+}
+
+// IdentityImportResource defines the resource implementation.
+type IdentityImportResource struct {
+	client *client.OryClient
+}
+
+// IdentityImportResourceModel describes the resource data model.
+type IdentityImportResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Source            types.String `tfsdk:"source"`
+	Format            types.String `tfsdk:"format"`
+	SchemaID          types.String `tfsdk:"schema_id"`
+	BatchSize         types.Int64  `tfsdk:"batch_size"`
+	RequestsPerSecond types.Int64  `tfsdk:"requests_per_second"`
+	ContentHash       types.String `tfsdk:"content_hash"`
+	Identities        types.Map    `tfsdk:"identities"`
+	RecordHashes      types.Map    `tfsdk:"record_hashes"`
+	ImportedCount     types.Int64  `tfsdk:"imported_count"`
+	FailedCount       types.Int64  `tfsdk:"failed_count"`
+}
+
+// importFailure is a record that could not be imported.
+type importFailure struct {
+	line    int
+	key     string
+	message string
+}
+
+func (r *IdentityImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_import"
+}
+
+const identityImportMarkdownDescription = `
+Imports identities in bulk from a JSONL or CSV file.
+
+Use this resource instead of one ` + "`ory_identity`" + ` per user when migrating large user bases.
+New records are created in batches through the batch identity API, changed records are updated
+individually, and requests are rate limited on the client side. Only records that changed since the
+last apply are sent.
+
+**Note:** Requires project_slug and project_api_key to be configured.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "ory_identity_import" "legacy_users" {
+  source    = "${path.module}/users.jsonl"
+  schema_id = "preset://email"
+}
+` + "```" + `
+`
+
+func (r *IdentityImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Imports identities in bulk from a JSONL or CSV file.",
+		MarkdownDescription: identityImportMarkdownDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal Terraform ID (the source path at creation).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "Path to the JSONL or CSV file with the identities to import.",
+				Required:    true,
+			},
+			"format": schema.StringAttribute{
+				Description: "File format: jsonl or csv. Inferred from the source file extension when not set (.csv is CSV, anything else JSONL).",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.IdentityImportFormatJSONL, helpers.IdentityImportFormatCSV),
+				},
+			},
+			"schema_id": schema.StringAttribute{
+				Description: "Identity schema ID for records that do not set their own schema_id.",
+				Optional:    true,
+			},
+			"batch_size": schema.Int64Attribute{
+				Description: "Number of identities created per batch request (default: 500, maximum: 2000).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(500),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 2000),
+				},
+			},
+			"requests_per_second": schema.Int64Attribute{
+				Description: "Maximum number of API requests sent per second (default: 5, maximum: 1000).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(5),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Description: "SHA-256 hash of the parsed records, excluding passwords and password hashes.",
+				Computed:    true,
+			},
+			"identities": schema.MapAttribute{
+				Description: "Map of record external_id to the ID of the imported identity.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"record_hashes": schema.MapAttribute{
+				Description: "Map of record external_id to the hash of the record content, excluding passwords and password hashes, at the last successful import.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"imported_count": schema.Int64Attribute{
+				Description: "Number of records imported successfully.",
+				Computed:    true,
+			},
+			"failed_count": schema.Int64Attribute{
+				Description: "Number of records that failed to import during the last apply.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *IdentityImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = oryClient
+}
+
+// ModifyPlan parses the source file so invalid records fail the plan, and
+// marks the import results unknown when records were added, changed, or
+// removed (or failed previously) even if the configuration is unchanged.
+func (r *IdentityImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan IdentityImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Source.IsUnknown() || plan.SchemaID.IsUnknown() {
+		return
+	}
+
+	if plan.Format.IsNull() || plan.Format.IsUnknown() {
+		plan.Format = types.StringValue(helpers.IdentityImportFormatFromPath(plan.Source.ValueString()))
+	}
+
+	records, contentHash, diags := readImportFile(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ContentHash = types.StringValue(contentHash)
+
+	pending := true
+	if !req.State.Raw.IsNull() {
+		var state IdentityImportResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		hashes := map[string]string{}
+		resp.Diagnostics.Append(state.RecordHashes.ElementsAs(ctx, &hashes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		pending = len(hashes) != len(records)
+		for _, rec := range records {
+			if hashes[rec.Key] != rec.Hash {
+				pending = true
+				break
+			}
+		}
+
+		if !pending {
+			plan.Identities = state.Identities
+			plan.RecordHashes = state.RecordHashes
+			plan.ImportedCount = state.ImportedCount
+			plan.FailedCount = state.FailedCount
+		}
+	}
+
+	if pending {
+		plan.Identities = types.MapUnknown(types.StringType)
+		plan.RecordHashes = types.MapUnknown(types.StringType)
+		plan.ImportedCount = types.Int64Unknown()
+		plan.FailedCount = types.Int64Unknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *IdentityImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IdentityImportResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := r.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, &resp.Diagnostics) {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.Source.ValueString())
+	resp.Diagnostics.Append(r.importRecords(ctx, &plan, map[string]string{}, map[string]string{})...)
+	if plan.Identities.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read keeps the recorded import results. Imported identities are not
+// read back individually, as imports typically cover many thousands of
+// records.
+func (r *IdentityImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IdentityImportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *IdentityImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state IdentityImportResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := r.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, &resp.Diagnostics) {
+		return
+	}
+
+	identities := map[string]string{}
+	hashes := map[string]string{}
+	resp.Diagnostics.Append(state.Identities.ElementsAs(ctx, &identities, false)...)
+	resp.Diagnostics.Append(state.RecordHashes.ElementsAs(ctx, &hashes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.importRecords(ctx, &plan, identities, hashes)...)
+	if plan.Identities.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the resource from state. Imported identities are
+// left in place; manage them with ory_identity or delete them through the
+// Ory Console or API.
+func (r *IdentityImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// importRecords creates new records, updates changed ones, and records the
+// results in the model. New records whose external_id already exists in the
+// project, for example from an earlier apply that failed partway, are
+// adopted and updated instead. Records removed from the file are no longer
+// tracked. Records that fail are reported as warnings and left out of the
+// results so the next apply retries them.
+func (r *IdentityImportResource) importRecords(ctx context.Context, plan *IdentityImportResourceModel, priorIDs, priorHashes map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	records, contentHash, readDiags := readImportFile(*plan)
+	diags.Append(readDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if !plan.ContentHash.IsUnknown() && plan.ContentHash.ValueString() != contentHash {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Identity Import File Changed",
+			"The source file changed after the plan was created. Run terraform plan again.",
+		)
+		return diags
+	}
+	plan.ContentHash = types.StringValue(contentHash)
+	if plan.Format.IsNull() || plan.Format.IsUnknown() {
+		plan.Format = types.StringValue(helpers.IdentityImportFormatFromPath(plan.Source.ValueString()))
+	}
+
+	identities := map[string]string{}
+	hashes := map[string]string{}
+	var toCreate []helpers.IdentityImportRecord
+	var toUpdate []helpers.IdentityImportRecord
+	for _, rec := range records {
+		id, ok := priorIDs[rec.Key]
+		switch {
+		case !ok:
+			toCreate = append(toCreate, rec)
+		case priorHashes[rec.Key] != rec.Hash:
+			identities[rec.Key] = id
+			hashes[rec.Key] = priorHashes[rec.Key]
+			toUpdate = append(toUpdate, rec)
+		default:
+			identities[rec.Key] = id
+			hashes[rec.Key] = rec.Hash
+		}
+	}
+
+	throttle := time.NewTicker(time.Second / time.Duration(plan.RequestsPerSecond.ValueInt64()))
+	defer throttle.Stop()
+
+	var failures []importFailure
+	var conflicts []helpers.IdentityImportRecord
+	var interrupted error
+
+	batchSize := int(plan.BatchSize.ValueInt64())
+	for start := 0; start < len(toCreate) && interrupted == nil; start += batchSize {
+		batch := toCreate[start:min(start+batchSize, len(toCreate))]
+
+		if interrupted = waitForThrottle(ctx, throttle); interrupted != nil {
+			break
+		}
+
+		patches := make([]ory.IdentityPatch, len(batch))
+		byKey := make(map[string]helpers.IdentityImportRecord, len(batch))
+		for i, rec := range batch {
+			body := rec.Body
+			patches[i] = ory.IdentityPatch{Create: &body, PatchId: ory.PtrString(rec.Key)}
+			byKey[rec.Key] = rec
+		}
+
+		results, err := r.client.BatchPatchIdentities(ctx, patches)
+		if err != nil {
+			for _, rec := range batch {
+				failures = append(failures, importFailure{line: rec.Line, key: rec.Key, message: err.Error()})
+			}
+			continue
+		}
+
+		for _, result := range results {
+			rec, ok := byKey[result.GetPatchId()]
+			if !ok {
+				continue
+			}
+			delete(byKey, rec.Key)
+
+			if result.GetAction() == "error" || result.Identity == nil {
+				if isConflict(result.Error) {
+					conflicts = append(conflicts, rec)
+					continue
+				}
+				failures = append(failures, importFailure{line: rec.Line, key: rec.Key, message: describePatchError(result.Error)})
+				continue
+			}
+			identities[rec.Key] = result.GetIdentity()
+			hashes[rec.Key] = rec.Hash
+		}
+		for _, rec := range byKey {
+			failures = append(failures, importFailure{line: rec.Line, key: rec.Key, message: "no result returned for record"})
+		}
+	}
+
+	for _, rec := range conflicts {
+		if interrupted != nil {
+			break
+		}
+		if interrupted = waitForThrottle(ctx, throttle); interrupted != nil {
+			break
+		}
+
+		existing, err := r.client.GetIdentityByExternalID(ctx, rec.Key)
+		if err != nil {
+			failures = append(failures, importFailure{line: rec.Line, key: rec.Key, message: "identity already exists and could not be looked up by external_id: " + err.Error()})
+			continue
+		}
+		identities[rec.Key] = existing.Id
+		toUpdate = append(toUpdate, rec)
+	}
+
+	for _, rec := range toUpdate {
+		if interrupted != nil {
+			break
+		}
+		if interrupted = waitForThrottle(ctx, throttle); interrupted != nil {
+			break
+		}
+
+		body := ory.UpdateIdentityBody{
+			ExternalId:     rec.Body.ExternalId,
+			SchemaId:       rec.Body.SchemaId,
+			State:          "active",
+			Traits:         rec.Body.Traits,
+			MetadataPublic: rec.Body.MetadataPublic,
+			MetadataAdmin:  rec.Body.MetadataAdmin,
+			Credentials:    rec.Body.Credentials,
+		}
+		if rec.Body.State != nil {
+			body.State = *rec.Body.State
+		}
+
+		if _, err := r.client.UpdateIdentity(ctx, identities[rec.Key], body); err != nil {
+			failures = append(failures, importFailure{line: rec.Line, key: rec.Key, message: err.Error()})
+			continue
+		}
+		hashes[rec.Key] = rec.Hash
+	}
+
+	for i, f := range failures {
+		if i == maxReportedFailures {
+			diags.AddWarning(
+				"Identity Import Incomplete",
+				fmt.Sprintf("%d more records failed to import. They will be retried on the next apply.", len(failures)-maxReportedFailures),
+			)
+			break
+		}
+		diags.AddWarning(
+			"Identity Import Record Failed",
+			fmt.Sprintf("Could not import the record on line %d (%s): %s", f.line, f.key, f.message),
+		)
+	}
+
+	if interrupted != nil {
+		diags.AddError(
+			"Identity Import Interrupted",
+			"The import was interrupted before all records were sent: "+interrupted.Error(),
+		)
+	}
+
+	// Records whose update failed keep their previous hash (or none, when
+	// adopted) so they are retried; records that failed to create are not
+	// tracked at all.
+	var mapDiags diag.Diagnostics
+	plan.Identities, mapDiags = types.MapValueFrom(ctx, types.StringType, identities)
+	diags.Append(mapDiags...)
+	plan.RecordHashes, mapDiags = types.MapValueFrom(ctx, types.StringType, hashes)
+	diags.Append(mapDiags...)
+	plan.ImportedCount = types.Int64Value(int64(len(identities)))
+	plan.FailedCount = types.Int64Value(int64(len(failures)))
+
+	return diags
+}
+
+// readImportFile reads and parses the source file, returning the records
+// and the content hash of the file.
+func readImportFile(model IdentityImportResourceModel) ([]helpers.IdentityImportRecord, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	data, err := os.ReadFile(model.Source.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Error Reading Identity Import File",
+			err.Error(),
+		)
+		return nil, "", diags
+	}
+
+	format := model.Format.ValueString()
+	if model.Format.IsNull() || model.Format.IsUnknown() {
+		format = helpers.IdentityImportFormatFromPath(model.Source.ValueString())
+	}
+
+	records, errs := helpers.ParseIdentityImportFile(data, format, model.SchemaID.ValueString())
+	for i, e := range errs {
+		if i == maxReportedFailures {
+			diags.AddAttributeError(
+				path.Root("source"),
+				"Invalid Identity Import File",
+				fmt.Sprintf("%d more invalid records.", len(errs)-maxReportedFailures),
+			)
+			break
+		}
+		diags.AddAttributeError(path.Root("source"), "Invalid Identity Import File", e.Error())
+	}
+
+	return records, helpers.IdentityImportContentHash(records), diags
+}
+
+// waitForThrottle blocks until the rate limiter allows the next request.
+func waitForThrottle(ctx context.Context, throttle *time.Ticker) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-throttle.C:
+		return nil
+	}
+}
+
+// isConflict reports whether a batch patch error is a conflict with an
+// existing identity.
+func isConflict(e interface{}) bool {
+	m, ok := e.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if code, ok := m["code"].(float64); ok {
+		return code == 409
+	}
+	return m["status"] == "Conflict"
+}
+
+// describePatchError extracts a readable message from a batch patch error.
+func describePatchError(e interface{}) string {
+	if e == nil {
+		return "unknown error"
+	}
+	if m, ok := e.(map[string]interface{}); ok {
+		for _, field := range []string{"reason", "message"} {
+			if s, ok := m[field].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	out, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Sprint(e)
+	}
+	return string(out)
+}
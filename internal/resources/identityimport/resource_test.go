@@ -0,0 +1,113 @@
+//go:build acceptance
+
+package identityimport_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func writeImportFile(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("writing import file: %v", err)
+	}
+}
+
+// trackImportedIdentities records the imported identity IDs so they can be
+// deleted after the test; destroying the resource leaves them in place.
+func trackImportedIdentities(ids map[string]bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["ory_identity_import.test"]
+		if !ok {
+			return fmt.Errorf("resource not found in state")
+		}
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "identities.") && k != "identities.%" {
+				ids[v] = true
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccIdentityImportResource_basic(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "users.jsonl")
+	prefix := fmt.Sprintf("tf-import-%d", time.Now().UnixNano())
+	record := func(n int, name string) string {
+		return fmt.Sprintf(`{"external_id":"%s-%d","traits":{"username":"%s-%s"}}`, prefix, n, prefix, name)
+	}
+
+	ids := map[string]bool{}
+	t.Cleanup(func() {
+		c, err := acctest.GetOryClient()
+		if err != nil {
+			return
+		}
+		for id := range ids {
+			_ = c.DeleteIdentity(context.Background(), id)
+		}
+	})
+
+	config := acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"Source": source})
+
+	acctest.RunTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Invalid records fail the plan
+			{
+				PreConfig:   func() { writeImportFile(t, source, record(1, "alice"), `{"traits":`) },
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`line 2: invalid JSON`),
+			},
+			// Create
+			{
+				PreConfig: func() { writeImportFile(t, source, record(1, "alice"), record(2, "bob"), record(3, "carol")) },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_import.test", "format", "jsonl"),
+					resource.TestCheckResourceAttr("ory_identity_import.test", "imported_count", "3"),
+					resource.TestCheckResourceAttr("ory_identity_import.test", "failed_count", "0"),
+					resource.TestCheckResourceAttr("ory_identity_import.test", "identities.%", "3"),
+					resource.TestCheckResourceAttrSet("ory_identity_import.test", "content_hash"),
+					trackImportedIdentities(ids),
+				),
+			},
+			// Add one record and change another
+			{
+				PreConfig: func() {
+					writeImportFile(t, source, record(1, "alice"), record(2, "bobby"), record(3, "carol"), record(4, "dave"))
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_import.test", "imported_count", "4"),
+					resource.TestCheckResourceAttr("ory_identity_import.test", "failed_count", "0"),
+					trackImportedIdentities(ids),
+				),
+			},
+			// Replacing the resource adopts the identities imported before
+			{
+				Taint:  []string{"ory_identity_import.test"},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_import.test", "imported_count", "4"),
+					resource.TestCheckResourceAttr("ory_identity_import.test", "failed_count", "0"),
+					trackImportedIdentities(ids),
+				),
+			},
+		},
+	})
+}
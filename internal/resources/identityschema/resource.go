@@ -5,14 +5,29 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
@@ -20,8 +35,19 @@ import (
 )
 
 var (
-	_ resource.Resource              = &IdentitySchemaResource{}
-	_ resource.ResourceWithConfigure = &IdentitySchemaResource{}
+	_ resource.Resource               = &IdentitySchemaResource{}
+	_ resource.ResourceWithConfigure  = &IdentitySchemaResource{}
+	_ resource.ResourceWithModifyPlan = &IdentitySchemaResource{}
+
+	_ planmodifier.String = generatedSchema{}
+)
+
+const (
+	// maxReportedFailures caps the number of per-identity migration warnings.
+	maxReportedFailures = 20
+	// maxSamplePages caps the identity pages scanned by the compatibility
+	// check, so a plan never walks a large project.
+	maxSamplePages = 10
 )
 
 func NewResource() resource.Resource {
@@ -33,11 +59,38 @@ type IdentitySchemaResource struct {
 }
 
 type IdentitySchemaResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	ProjectID  types.String `tfsdk:"project_id"`
-	SchemaID   types.String `tfsdk:"schema_id"`
-	Schema     types.String `tfsdk:"schema"`
-	SetDefault types.Bool   `tfsdk:"set_default"`
+	ID         types.String           `tfsdk:"id"`
+	ProjectID  types.String           `tfsdk:"project_id"`
+	SchemaID   types.String           `tfsdk:"schema_id"`
+	Schema     helpers.NormalizedJSON `tfsdk:"schema"`
+	Preset     types.String           `tfsdk:"preset"`
+	Traits     types.List             `tfsdk:"traits"`
+	SetDefault types.Bool             `tfsdk:"set_default"`
+
+	Versioned          types.Bool  `tfsdk:"versioned"`
+	Version            types.Int64 `tfsdk:"version"`
+	MigrateIdentities  types.Bool  `tfsdk:"migrate_identities"`
+	MigrationBatchSize types.Int64 `tfsdk:"migration_batch_size"`
+	MigratedCount      types.Int64 `tfsdk:"migrated_count"`
+	MigrationFailures  types.Map   `tfsdk:"migration_failures"`
+
+	CompatibilityCheck      types.String `tfsdk:"compatibility_check"`
+	CompatibilitySampleSize types.Int64  `tfsdk:"compatibility_sample_size"`
+}
+
+// SchemaTraitModel describes a trait of a generated schema.
+type SchemaTraitModel struct {
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Title        types.String `tfsdk:"title"`
+	Format       types.String `tfsdk:"format"`
+	Required     types.Bool   `tfsdk:"required"`
+	MinLength    types.Int64  `tfsdk:"min_length"`
+	MaxLength    types.Int64  `tfsdk:"max_length"`
+	Pattern      types.String `tfsdk:"pattern"`
+	Credentials  types.List   `tfsdk:"credentials"`
+	Verification types.Bool   `tfsdk:"verification"`
+	Recovery     types.Bool   `tfsdk:"recovery"`
 }
 
 func (r *IdentitySchemaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,7 +102,7 @@ Manages an Ory Network identity schema.
 
 ## Important Notes
 
-- **Schemas are immutable**: Identity schemas cannot be modified after creation. Any changes to the schema content or ` + "`schema_id`" + ` will require Terraform to destroy and recreate the resource.
+- **Schemas are immutable**: Identity schemas cannot be modified after creation. Any changes to the schema content or ` + "`schema_id`" + ` will require Terraform to destroy and recreate the resource, unless ` + "`versioned`" + ` is set. Reformatting the schema (key order, whitespace) is not a change.
 - **Schemas cannot be deleted**: When this resource is destroyed, the schema remains in Ory but is no longer managed by Terraform.
 - **Import is not supported**: Existing schemas created via the Ory Console or API cannot be imported into Terraform. To manage an existing schema, recreate it in your Terraform configuration using the same content.
 
@@ -127,11 +180,90 @@ func (r *IdentitySchemaResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"schema": schema.StringAttribute{
-				Description: "JSON Schema definition for the identity traits (JSON string).",
-				Required:    true,
+				Description: "JSON Schema definition for the identity traits (JSON string). Exactly one of schema, preset or traits must be set; with preset or traits, this is the generated schema.",
+				CustomType:  helpers.NormalizedJSONType{},
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("preset"), path.MatchRoot("traits")),
+				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(), // Schemas are immutable
-					stringplanmodifier.UseStateForUnknown(),
+					generatedSchema{},
+					schemaRequiresReplace(), // Schemas are immutable
+				},
+			},
+			"preset": schema.StringAttribute{
+				Description: "Generate the schema from a preset: 'email_password', 'username', 'phone_code' or 'passkey'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.SchemaPresets...),
+				},
+			},
+			"traits": schema.ListNestedAttribute{
+				Description: "Generate the schema from these traits. Credential, verification and recovery annotations are derived from each trait.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Trait name, e.g. 'email'.",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Trait type: 'string' (default), 'number', 'integer' or 'boolean'.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("string"),
+							Validators: []validator.String{
+								stringvalidator.OneOf(helpers.SchemaTraitTypes...),
+							},
+						},
+						"title": schema.StringAttribute{
+							Description: "Label shown in the login and registration UI.",
+							Optional:    true,
+						},
+						"format": schema.StringAttribute{
+							Description: "JSON Schema format, e.g. 'email' or 'tel'. Codes, verification and recovery messages are sent by email for 'email' and by SMS for 'tel'.",
+							Optional:    true,
+						},
+						"required": schema.BoolAttribute{
+							Description: "Whether identities must have this trait.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"min_length": schema.Int64Attribute{
+							Description: "Minimum length of string traits.",
+							Optional:    true,
+						},
+						"max_length": schema.Int64Attribute{
+							Description: "Maximum length of string traits.",
+							Optional:    true,
+						},
+						"pattern": schema.StringAttribute{
+							Description: "Regular expression string traits must match.",
+							Optional:    true,
+						},
+						"credentials": schema.ListAttribute{
+							Description: "Credential methods using this trait: 'password', 'code' and 'webauthn' as login identifier, 'passkey' as display name, 'totp' as account name. 'code' requires format 'email' or 'tel'.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.OneOf(helpers.SchemaTraitCredentials...)),
+							},
+						},
+						"verification": schema.BoolAttribute{
+							Description: "Verify this address. Requires format 'email' or 'tel'.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"recovery": schema.BoolAttribute{
+							Description: "Use this address for account recovery. Requires format 'email' or 'tel'.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+					},
 				},
 			},
 			"set_default": schema.BoolAttribute{
@@ -140,6 +272,61 @@ func (r *IdentitySchemaResource) Schema(ctx context.Context, req resource.Schema
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"versioned": schema.BoolAttribute{
+				Description: "Register each revision of the schema under a new ID (schema_id-v1, schema_id-v2, ...) instead of replacing the resource when the content changes.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "The current schema version when versioned is set. Incremented on every content change.",
+				Computed:    true,
+			},
+			"migrate_identities": schema.BoolAttribute{
+				Description: "When a new version is created, move identities using an earlier version to it. Requires versioned, project_slug and project_api_key.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"migration_batch_size": schema.Int64Attribute{
+				Description: "Number of identities listed and migrated per batch (default: 250).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(250),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"migrated_count": schema.Int64Attribute{
+				Description: "Number of identities moved to the current version by the last migration.",
+				Computed:    true,
+			},
+			"migration_failures": schema.MapAttribute{
+				Description: "Identities the last migration could not move, keyed by identity ID, with the reason. They stay on their previous schema version.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"compatibility_check": schema.StringAttribute{
+				Description: "How to report schema changes that existing identities may not satisfy: 'off', 'warn' (default) or 'error'. Narrowing changes are always reported as warnings.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("warn"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("off", "warn", "error"),
+				},
+			},
+			"compatibility_sample_size": schema.Int64Attribute{
+				Description: "Number of existing identities on this schema to validate against the new content during plan (default: 0, disabled). Requires project_slug and project_api_key.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+			},
 		},
 	}
 }
@@ -157,6 +344,373 @@ func (r *IdentitySchemaResource) Configure(ctx context.Context, req resource.Con
 	r.client = oryClient
 }
 
+// schemaRequiresReplace replaces the resource when the schema content
+// changes, unless the schema is versioned; versioned schemas register the new
+// content under a new ID in place.
+func schemaRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var versioned types.Bool
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("versioned"), &versioned)...)
+			if versioned.ValueBool() {
+				return
+			}
+			resp.RequiresReplace = !helpers.JSONSemanticallyEqual(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"If the schema content changes and the schema is not versioned, Terraform will destroy and recreate the resource.",
+		"If the schema content changes and the schema is not versioned, Terraform will destroy and recreate the resource.",
+	)
+}
+
+// generatedSchema plans the schema generated from preset or traits when no
+// schema is configured. It runs before schemaRequiresReplace, so changing the
+// preset or traits is handled like a change of the schema content.
+type generatedSchema struct{}
+
+func (m generatedSchema) Description(ctx context.Context) string {
+	return "Generates the schema from preset or traits."
+}
+
+func (m generatedSchema) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m generatedSchema) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() || !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var config IdentitySchemaResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	traits, known, diags := schemaTraits(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !known || config.SchemaID.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	if traits == nil {
+		// Neither preset nor traits are set; reported by the validators.
+		return
+	}
+
+	attrPath := path.Root("traits")
+	if !config.Preset.IsNull() {
+		attrPath = path.Root("preset")
+	}
+	identitySchema, err := helpers.BuildIdentitySchema(config.SchemaID.ValueString(), traits)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attrPath, "Invalid Identity Schema Definition", err.Error())
+		return
+	}
+	generated, err := helpers.NewNormalizedJSONFromObject(identitySchema)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attrPath, "Invalid Identity Schema Definition", err.Error())
+		return
+	}
+	resp.PlanValue = generated.StringValue
+}
+
+// schemaTraits returns the traits of the configured preset or traits list,
+// or nil if neither is set. known is false if any of them is not yet known.
+func schemaTraits(ctx context.Context, config IdentitySchemaResourceModel) (traits []helpers.SchemaTrait, known bool, diags diag.Diagnostics) {
+	if config.Preset.IsUnknown() || config.Traits.IsUnknown() {
+		return nil, false, diags
+	}
+
+	if !config.Preset.IsNull() {
+		traits, err := helpers.PresetTraits(config.Preset.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("preset"), "Invalid Identity Schema Preset", err.Error())
+		}
+		return traits, true, diags
+	}
+	if config.Traits.IsNull() {
+		return nil, true, diags
+	}
+
+	var models []SchemaTraitModel
+	diags.Append(config.Traits.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+
+	traits = make([]helpers.SchemaTrait, 0, len(models))
+	for _, m := range models {
+		values := []attr.Value{m.Name, m.Type, m.Title, m.Format, m.Required, m.MinLength, m.MaxLength, m.Pattern, m.Credentials, m.Verification, m.Recovery}
+		for _, v := range values {
+			if v.IsUnknown() {
+				return nil, false, diags
+			}
+		}
+
+		var credentials []types.String
+		diags.Append(m.Credentials.ElementsAs(ctx, &credentials, false)...)
+		if diags.HasError() {
+			return nil, false, diags
+		}
+
+		trait := helpers.SchemaTrait{
+			Name:         m.Name.ValueString(),
+			Type:         m.Type.ValueString(),
+			Title:        m.Title.ValueString(),
+			Format:       m.Format.ValueString(),
+			Required:     m.Required.ValueBool(),
+			MinLength:    m.MinLength.ValueInt64Pointer(),
+			MaxLength:    m.MaxLength.ValueInt64Pointer(),
+			Pattern:      m.Pattern.ValueString(),
+			Verification: m.Verification.ValueBool(),
+			Recovery:     m.Recovery.ValueBool(),
+		}
+		for _, c := range credentials {
+			if c.IsUnknown() {
+				return nil, false, diags
+			}
+			trait.Credentials = append(trait.Credentials, c.ValueString())
+		}
+		traits = append(traits, trait)
+	}
+	return traits, true, diags
+}
+
+// versionedSchemaID returns the project schema ID of a schema version.
+func versionedSchemaID(schemaID string, version int64) string {
+	return fmt.Sprintf("%s-v%d", schemaID, version)
+}
+
+// effectiveSchemaID returns the ID the schema is registered under in the
+// project: schema_id, or schema_id-v<version> for versioned schemas.
+func effectiveSchemaID(m IdentitySchemaResourceModel) string {
+	if m.Versioned.ValueBool() && !m.Version.IsNull() && !m.Version.IsUnknown() {
+		return versionedSchemaID(m.SchemaID.ValueString(), m.Version.ValueInt64())
+	}
+	if m.Versioned.ValueBool() {
+		return versionedSchemaID(m.SchemaID.ValueString(), 1)
+	}
+	return m.SchemaID.ValueString()
+}
+
+// ModifyPlan checks content changes for compatibility with existing
+// identities and plans the computed version and migration attributes: a
+// content change of a versioned schema bumps the version and, with
+// migrate_identities, triggers a new migration. Otherwise they keep their
+// state values.
+func (r *IdentitySchemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan IdentitySchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.MigrateIdentities.ValueBool() && !plan.Versioned.IsUnknown() && !plan.Versioned.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("migrate_identities"), "Invalid Attribute Combination",
+			"migrate_identities requires versioned = true.")
+		return
+	}
+
+	var state IdentitySchemaResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.checkCompatibility(ctx, plan, state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	emptyFailures := types.MapValueMust(types.StringType, map[string]attr.Value{})
+
+	// A replacement starts over at version 1, like a create.
+	if req.State.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		if plan.Versioned.ValueBool() {
+			plan.Version = types.Int64Value(1)
+		} else if !plan.Versioned.IsUnknown() {
+			plan.Version = types.Int64Null()
+		}
+		plan.MigratedCount = types.Int64Value(0)
+		plan.MigrationFailures = emptyFailures
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	plan.Version = state.Version
+	plan.MigratedCount = state.MigratedCount
+	plan.MigrationFailures = state.MigrationFailures
+	if plan.MigratedCount.IsNull() {
+		plan.MigratedCount = types.Int64Value(0)
+	}
+	if plan.MigrationFailures.IsNull() {
+		plan.MigrationFailures = emptyFailures
+	}
+
+	// Unknown content may or may not differ from the state; Update resolves
+	// the version once the content is known.
+	if plan.Versioned.ValueBool() && plan.Schema.IsUnknown() {
+		plan.Version = types.Int64Unknown()
+		plan.ID = types.StringUnknown()
+		plan.MigratedCount = types.Int64Unknown()
+		plan.MigrationFailures = types.MapUnknown(types.StringType)
+	}
+
+	if plan.Versioned.ValueBool() && !plan.Schema.IsUnknown() &&
+		!helpers.JSONSemanticallyEqual(state.Schema.ValueString(), plan.Schema.ValueString()) {
+		plan.Version = nextVersion(state)
+		plan.ID = types.StringUnknown()
+		if plan.MigrateIdentities.ValueBool() {
+			plan.MigratedCount = types.Int64Unknown()
+			plan.MigrationFailures = types.MapUnknown(types.StringType)
+		} else {
+			plan.MigratedCount = types.Int64Value(0)
+			plan.MigrationFailures = emptyFailures
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// nextVersion returns the version a content change of the schema in state
+// is registered under.
+func nextVersion(state IdentitySchemaResourceModel) types.Int64 {
+	version := int64(1)
+	if !state.Version.IsNull() {
+		version = state.Version.ValueInt64()
+	}
+	return types.Int64Value(version + 1)
+}
+
+// checkCompatibility classifies the difference between the state and plan
+// schema content and reports breaking and narrowing changes. With
+// compatibility_sample_size, existing identities on the schema are validated
+// against the new content as well.
+func (r *IdentitySchemaResource) checkCompatibility(ctx context.Context, plan, state IdentitySchemaResourceModel, diags *diag.Diagnostics) {
+	mode := plan.CompatibilityCheck.ValueString()
+	if mode == "off" || plan.Schema.IsUnknown() || state.Schema.IsNull() ||
+		helpers.JSONSemanticallyEqual(state.Schema.ValueString(), plan.Schema.ValueString()) {
+		return
+	}
+
+	var oldSchema, newSchema map[string]interface{}
+	if err := state.Schema.Unmarshal(&oldSchema); err != nil {
+		return
+	}
+	if err := plan.Schema.Unmarshal(&newSchema); err != nil {
+		// Invalid JSON is reported by the attribute validation.
+		return
+	}
+
+	addBreaking := diags.AddAttributeWarning
+	if mode == "error" {
+		addBreaking = diags.AddAttributeError
+	}
+
+	byKind := map[string][]string{}
+	for _, change := range helpers.CompareIdentitySchemas(oldSchema, newSchema) {
+		if change.Kind == helpers.SchemaChangeAdditive {
+			tflog.Debug(ctx, "Additive identity schema change", map[string]interface{}{"path": change.Path, "change": change.Message})
+			continue
+		}
+		byKind[change.Kind] = append(byKind[change.Kind], "- "+change.Error())
+	}
+	if changes := byKind[helpers.SchemaChangeBreaking]; len(changes) > 0 {
+		addBreaking(path.Root("schema"), "Breaking Identity Schema Change",
+			"The new schema content may reject or lock out existing identities:\n\n"+strings.Join(changes, "\n")+
+				"\n\nConsider versioned = true with migrate_identities, or set compatibility_check = \"off\" to skip this check.")
+	}
+	if changes := byKind[helpers.SchemaChangeNarrowing]; len(changes) > 0 {
+		diags.AddAttributeWarning(path.Root("schema"), "Narrowing Identity Schema Change",
+			"The new schema content may reject some existing identities:\n\n"+strings.Join(changes, "\n"))
+	}
+
+	sampleSize := plan.CompatibilitySampleSize.ValueInt64()
+	if sampleSize <= 0 || r.client == nil {
+		return
+	}
+	cfg := r.client.Config()
+	if cfg.ProjectSlug == "" || cfg.ProjectAPIKey == "" {
+		diags.AddAttributeWarning(path.Root("compatibility_sample_size"), "Identity Sample Skipped",
+			"Validating existing identities requires project_slug and project_api_key.")
+		return
+	}
+
+	failures, err := r.sampleIdentities(ctx, state.ID.ValueString(), newSchema, sampleSize)
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("compatibility_sample_size"), "Identity Sample Skipped",
+			fmt.Sprintf("Could not list identities: %s", err.Error()))
+		return
+	}
+	if len(failures) == 0 {
+		return
+	}
+
+	ids := slices.Sorted(maps.Keys(failures))
+	lines := make([]string, 0, maxReportedFailures+1)
+	for i, id := range ids {
+		if i == maxReportedFailures {
+			lines = append(lines, fmt.Sprintf("- ... and %d more", len(ids)-maxReportedFailures))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", id, failures[id]))
+	}
+	addBreaking(path.Root("schema"), "Identities Incompatible With New Schema",
+		fmt.Sprintf("%d sampled identities do not satisfy the new schema content:\n\n%s", len(ids), strings.Join(lines, "\n")))
+}
+
+// sampleIdentities validates up to sampleSize identities using schemaID
+// against identitySchema and returns the validation errors by identity ID.
+func (r *IdentitySchemaResource) sampleIdentities(ctx context.Context, schemaID string, identitySchema map[string]interface{}, sampleSize int64) (map[string]string, error) {
+	failures := map[string]string{}
+	var sampled int64
+
+	pageToken := ""
+	for page := 0; page < maxSamplePages; page++ {
+		identities, next, err := r.client.ListIdentitiesPage(ctx, sampleSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, identity := range identities {
+			if identity.SchemaId != schemaID {
+				continue
+			}
+			traitErrors, err := helpers.ValidateTraits(identitySchema, identity.Traits)
+			if err != nil {
+				// Schemas with remote references cannot be checked locally.
+				tflog.Debug(ctx, "Skipping identity sample", map[string]interface{}{"error": err.Error()})
+				return failures, nil
+			}
+			if len(traitErrors) > 0 {
+				messages := make([]string, len(traitErrors))
+				for i, e := range traitErrors {
+					messages[i] = e.Error()
+				}
+				failures[identity.Id] = strings.Join(messages, "; ")
+			}
+			sampled++
+			if sampled == sampleSize {
+				return failures, nil
+			}
+		}
+
+		if next == "" {
+			break
+		}
+		pageToken = next
+	}
+	return failures, nil
+}
+
 func (r *IdentitySchemaResource) encodeSchema(schemaJSON string) (string, error) {
 	// Validate it's valid JSON
 	var schemaMap map[string]interface{}
@@ -207,43 +761,36 @@ func (r *IdentitySchemaResource) findSchemaIndex(schemas []map[string]interface{
 	return -1
 }
 
-// findSchemaByURL finds a schema by matching its URL content.
-// This is needed because Ory API may transform custom schema IDs to hash-based IDs.
-func (r *IdentitySchemaResource) findSchemaByURL(schemas []map[string]interface{}, schemaURL string) int {
+// findSchemaByContent finds a schema whose base64:// URL encodes the given
+// JSON document. Documents are compared semantically, so key order and
+// whitespace do not matter. This is needed because Ory API may transform
+// custom schema IDs to hash-based IDs.
+func (r *IdentitySchemaResource) findSchemaByContent(schemas []map[string]interface{}, schemaJSON string) int {
 	for i, s := range schemas {
-		if url, ok := s["url"].(string); ok && url == schemaURL {
+		url, ok := s["url"].(string)
+		if !ok || !strings.HasPrefix(url, "base64://") {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(url, "base64://"))
+		if err != nil {
+			continue
+		}
+		if helpers.JSONSemanticallyEqual(string(decoded), schemaJSON) {
 			return i
 		}
 	}
 	return -1
 }
 
-func (r *IdentitySchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan IdentitySchemaResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	schemaID := plan.SchemaID.ValueString()
-	schemaJSON := plan.Schema.ValueString()
-
-	schemaURL, err := r.encodeSchema(schemaJSON)
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid Schema", err.Error())
-		return
-	}
-
+// putSchema adds the schema to the project under schemaID, replacing an
+// existing entry with the same ID, and returns the canonical ID the API
+// assigned to it. With setDefault the schema also becomes the project's
+// default schema in the same API call.
+func (r *IdentitySchemaResource) putSchema(ctx context.Context, projectID, schemaID, schemaJSON, schemaURL string, setDefault bool) (string, error) {
 	// Get existing schemas and their IDs before the patch
 	existingSchemas, err := r.getSchemas(ctx, projectID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error Getting Schemas", err.Error())
-		return
+		return "", fmt.Errorf("getting schemas: %w", err)
 	}
 
 	existingIDs := make(map[string]bool)
@@ -280,7 +827,7 @@ func (r *IdentitySchemaResource) Create(ctx context.Context, req resource.Create
 
 	// If set_default is true, include the default_schema_id patch in the same
 	// API call to avoid a race condition with eventual consistency.
-	if plan.SetDefault.ValueBool() {
+	if setDefault {
 		patches = append(patches, ory.JsonPatch{
 			Op:    "add",
 			Path:  "/services/identity/config/identity/default_schema_id",
@@ -288,10 +835,8 @@ func (r *IdentitySchemaResource) Create(ctx context.Context, req resource.Create
 		})
 	}
 
-	_, err = r.client.PatchProject(ctx, projectID, patches)
-	if err != nil {
-		resp.Diagnostics.AddError("Error Creating Identity Schema", err.Error())
-		return
+	if _, err := r.client.PatchProject(ctx, projectID, patches); err != nil {
+		return "", err
 	}
 
 	// Resolve the canonical schema ID using a fresh GetProject call.
@@ -313,8 +858,8 @@ func (r *IdentitySchemaResource) Create(ctx context.Context, req resource.Create
 			}
 		}
 
-		// Try by URL match
-		if idx := r.findSchemaByURL(freshSchemas, schemaURL); idx >= 0 {
+		// Try by content match
+		if idx := r.findSchemaByContent(freshSchemas, schemaJSON); idx >= 0 {
 			if id, ok := freshSchemas[idx]["id"].(string); ok {
 				actualID = id
 				return true, nil
@@ -338,6 +883,40 @@ func (r *IdentitySchemaResource) Create(ctx context.Context, req resource.Create
 		actualID = schemaID
 	}
 
+	return actualID, nil
+}
+
+func (r *IdentitySchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IdentitySchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemaURL, err := r.encodeSchema(plan.Schema.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Schema", err.Error())
+		return
+	}
+
+	actualID, err := r.putSchema(ctx, projectID, effectiveSchemaID(plan), plan.Schema.ValueString(), schemaURL, plan.SetDefault.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Identity Schema", err.Error())
+		return
+	}
+
+	if plan.Versioned.ValueBool() {
+		plan.Version = types.Int64Value(1)
+	} else {
+		plan.Version = types.Int64Null()
+	}
+	plan.MigratedCount = types.Int64Value(0)
+	plan.MigrationFailures = types.MapValueMust(types.StringType, map[string]attr.Value{})
 	plan.ID = types.StringValue(actualID)
 	plan.ProjectID = types.StringValue(projectID)
 
@@ -358,16 +937,12 @@ func (r *IdentitySchemaResource) Read(ctx context.Context, req resource.ReadRequ
 	state.ProjectID = types.StringValue(projectID)
 
 	storedID := state.ID.ValueString()
-	schemaID := state.SchemaID.ValueString()
+	schemaID := effectiveSchemaID(state)
 
-	// Generate schemaURL for matching if we have schema content
-	var schemaURL string
+	// Use the schema content for matching if we have it
+	var schemaJSON string
 	if !state.Schema.IsNull() && !state.Schema.IsUnknown() {
-		var err error
-		schemaURL, err = r.encodeSchema(state.Schema.ValueString())
-		if err != nil {
-			schemaURL = ""
-		}
+		schemaJSON = state.Schema.ValueString()
 	}
 
 	var schemas []map[string]interface{}
@@ -381,8 +956,8 @@ func (r *IdentitySchemaResource) Read(ctx context.Context, req resource.ReadRequ
 		if index < 0 {
 			index = r.findSchemaIndex(schemas, schemaID)
 		}
-		if index < 0 && schemaURL != "" {
-			index = r.findSchemaByURL(schemas, schemaURL)
+		if index < 0 && schemaJSON != "" {
+			index = r.findSchemaByContent(schemas, schemaJSON)
 		}
 	}
 
@@ -404,8 +979,8 @@ func (r *IdentitySchemaResource) Read(ctx context.Context, req resource.ReadRequ
 				index = r.findSchemaIndex(schemas, schemaID)
 			}
 
-			if index < 0 && schemaURL != "" {
-				index = r.findSchemaByURL(schemas, schemaURL)
+			if index < 0 && schemaJSON != "" {
+				index = r.findSchemaByContent(schemas, schemaJSON)
 			}
 
 			if index >= 0 {
@@ -448,6 +1023,30 @@ func (r *IdentitySchemaResource) Read(ctx context.Context, req resource.ReadRequ
 		state.ID = types.StringValue(id)
 	}
 
+	// Versioning attributes are Terraform-only; fill them in for states
+	// written before they existed.
+	if state.Versioned.IsNull() {
+		state.Versioned = types.BoolValue(false)
+	}
+	if state.MigrateIdentities.IsNull() {
+		state.MigrateIdentities = types.BoolValue(false)
+	}
+	if state.MigrationBatchSize.IsNull() {
+		state.MigrationBatchSize = types.Int64Value(250)
+	}
+	if state.MigratedCount.IsNull() {
+		state.MigratedCount = types.Int64Value(0)
+	}
+	if state.MigrationFailures.IsNull() {
+		state.MigrationFailures = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+	if state.CompatibilityCheck.IsNull() {
+		state.CompatibilityCheck = types.StringValue("warn")
+	}
+	if state.CompatibilitySampleSize.IsNull() {
+		state.CompatibilitySampleSize = types.Int64Value(0)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -465,10 +1064,45 @@ func (r *IdentitySchemaResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	emptyFailures := types.MapValueMust(types.StringType, map[string]attr.Value{})
+
+	// The version is unknown when the schema content was unknown at plan
+	// time, so compare the content with the state now.
+	if plan.Versioned.ValueBool() && plan.Version.IsUnknown() {
+		plan.Version = state.Version
+		if !helpers.JSONSemanticallyEqual(state.Schema.ValueString(), plan.Schema.ValueString()) {
+			plan.Version = nextVersion(state)
+			if !plan.MigrateIdentities.ValueBool() {
+				plan.MigratedCount = types.Int64Value(0)
+				plan.MigrationFailures = emptyFailures
+			}
+		}
+	}
+	if plan.MigratedCount.IsUnknown() && plan.Version.Equal(state.Version) {
+		plan.MigratedCount = state.MigratedCount
+		plan.MigrationFailures = state.MigrationFailures
+		if plan.MigratedCount.IsNull() {
+			plan.MigratedCount = types.Int64Value(0)
+		}
+		if plan.MigrationFailures.IsNull() {
+			plan.MigrationFailures = emptyFailures
+		}
+	}
+
+	if plan.Versioned.ValueBool() && !plan.Version.Equal(state.Version) {
+		r.createVersion(ctx, projectID, &plan, state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.ProjectID = types.StringValue(projectID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
 	// Use the API-assigned ID from state (which may differ from schema_id)
 	apiID := state.ID.ValueString()
 	if apiID == "" {
-		apiID = plan.SchemaID.ValueString()
+		apiID = effectiveSchemaID(plan)
 	}
 
 	if plan.SetDefault.ValueBool() {
@@ -483,11 +1117,11 @@ func (r *IdentitySchemaResource) Update(ctx context.Context, req resource.Update
 			}
 
 			// Build list of candidate IDs to try: state ID, schema_id, and any URL-matched ID
-			candidateIDs := r.collectCandidateIDs(schemas, apiID, plan.SchemaID.ValueString(), state.Schema)
+			candidateIDs := r.collectCandidateIDs(schemas, apiID, effectiveSchemaID(plan), state.Schema)
 			if len(candidateIDs) == 0 {
 				// Schema not found yet — may be eventual consistency
 				lastErr = fmt.Errorf("schema not found in project (looked for id=%q or schema_id=%q, available: %v)",
-					apiID, plan.SchemaID.ValueString(), schemaIDList(schemas))
+					apiID, effectiveSchemaID(plan), schemaIDList(schemas))
 				return false, nil
 			}
 
@@ -525,6 +1159,129 @@ func (r *IdentitySchemaResource) Update(ctx context.Context, req resource.Update
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+// createVersion registers the planned content as a new schema version and,
+// with migrate_identities, moves identities from earlier versions to it.
+func (r *IdentitySchemaResource) createVersion(ctx context.Context, projectID string, plan *IdentitySchemaResourceModel, state IdentitySchemaResourceModel, diags *diag.Diagnostics) {
+	schemaURL, err := r.encodeSchema(plan.Schema.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Schema", err.Error())
+		return
+	}
+
+	newID, err := r.putSchema(ctx, projectID, effectiveSchemaID(*plan), plan.Schema.ValueString(), schemaURL, plan.SetDefault.ValueBool())
+	if err != nil {
+		diags.AddError("Error Creating Identity Schema Version", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(newID)
+
+	if !plan.MigrateIdentities.ValueBool() {
+		return
+	}
+
+	cfg := r.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, diags) {
+		return
+	}
+
+	// Identities may still use any earlier version, e.g. when a previous
+	// migration skipped them.
+	fromIDs := map[string]bool{state.ID.ValueString(): true}
+	for v := int64(1); v < plan.Version.ValueInt64(); v++ {
+		fromIDs[versionedSchemaID(plan.SchemaID.ValueString(), v)] = true
+	}
+	delete(fromIDs, newID)
+
+	var identitySchema map[string]interface{}
+	if err := plan.Schema.Unmarshal(&identitySchema); err != nil {
+		diags.AddError("Invalid Schema", err.Error())
+		return
+	}
+
+	migrated, failures, err := r.migrateIdentities(ctx, fromIDs, newID, identitySchema, plan.MigrationBatchSize.ValueInt64())
+
+	failureValues := make(map[string]attr.Value, len(failures))
+	ids := make([]string, 0, len(failures))
+	for id, reason := range failures {
+		failureValues[id] = types.StringValue(reason)
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	plan.MigratedCount = types.Int64Value(migrated)
+	plan.MigrationFailures = types.MapValueMust(types.StringType, failureValues)
+
+	for i, id := range ids {
+		if i == maxReportedFailures {
+			diags.AddWarning(
+				"Identity Migration Incomplete",
+				fmt.Sprintf("%d more identities could not be migrated. See migration_failures.", len(ids)-maxReportedFailures),
+			)
+			break
+		}
+		diags.AddWarning(
+			"Identity Not Migrated",
+			fmt.Sprintf("Identity %s stays on its previous schema version: %s", id, failures[id]),
+		)
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Identity Migration Interrupted",
+			fmt.Sprintf("Migrated %d identities to %s before the migration stopped: %s", migrated, newID, err.Error()),
+		)
+	}
+}
+
+// migrateIdentities moves all identities whose schema ID is in fromIDs to
+// toID, one page of batchSize identities at a time. Traits are validated
+// against the new schema first, and identities that do not match it are left
+// untouched. It returns the number of migrated identities and the reasons
+// for each identity that could not be migrated.
+func (r *IdentitySchemaResource) migrateIdentities(ctx context.Context, fromIDs map[string]bool, toID string, identitySchema map[string]interface{}, batchSize int64) (int64, map[string]string, error) {
+	var migrated int64
+	failures := map[string]string{}
+
+	pageToken := ""
+	for {
+		identities, next, err := r.client.ListIdentitiesPage(ctx, batchSize, pageToken)
+		if err != nil {
+			return migrated, failures, err
+		}
+
+		for _, identity := range identities {
+			if !fromIDs[identity.SchemaId] {
+				continue
+			}
+
+			// Schemas with remote references cannot be checked locally;
+			// the API validates the traits when patching.
+			if traitErrors, err := helpers.ValidateTraits(identitySchema, identity.Traits); err == nil && len(traitErrors) > 0 {
+				messages := make([]string, len(traitErrors))
+				for i, e := range traitErrors {
+					messages[i] = e.Error()
+				}
+				failures[identity.Id] = strings.Join(messages, "; ")
+				continue
+			}
+
+			patches := []ory.JsonPatch{{Op: "replace", Path: "/schema_id", Value: toID}}
+			if _, err := r.client.PatchIdentity(ctx, identity.Id, patches); err != nil {
+				if ctx.Err() != nil {
+					return migrated, failures, ctx.Err()
+				}
+				failures[identity.Id] = err.Error()
+				continue
+			}
+			migrated++
+		}
+
+		if next == "" {
+			return migrated, failures, nil
+		}
+		pageToken = next
+	}
+}
+
 func (r *IdentitySchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Ory Network does not support deleting identity schemas.
 	// See: https://github.com/ory/network/issues/262
@@ -538,7 +1295,7 @@ func (r *IdentitySchemaResource) Delete(ctx context.Context, req resource.Delete
 
 // collectCandidateIDs returns a deduplicated list of schema IDs that could be our schema.
 // It tries: stored ID, user-provided schema_id, URL match, and all schema IDs as fallback.
-func (r *IdentitySchemaResource) collectCandidateIDs(schemas []map[string]interface{}, storedID, schemaID string, schemaAttr types.String) []string {
+func (r *IdentitySchemaResource) collectCandidateIDs(schemas []map[string]interface{}, storedID, schemaID string, schemaAttr helpers.NormalizedJSON) []string {
 	seen := make(map[string]bool)
 	var candidates []string
 	addCandidate := func(id string) {
@@ -562,13 +1319,11 @@ func (r *IdentitySchemaResource) collectCandidateIDs(schemas []map[string]interf
 		}
 	}
 
-	// Priority 3: URL content match
+	// Priority 3: content match
 	if !schemaAttr.IsNull() && !schemaAttr.IsUnknown() {
-		if schemaURL, err := r.encodeSchema(schemaAttr.ValueString()); err == nil {
-			if idx := r.findSchemaByURL(schemas, schemaURL); idx >= 0 {
-				if id, ok := schemas[idx]["id"].(string); ok {
-					addCandidate(id)
-				}
+		if idx := r.findSchemaByContent(schemas, schemaAttr.ValueString()); idx >= 0 {
+			if id, ok := schemas[idx]["id"].(string); ok {
+				addCandidate(id)
 			}
 		}
 	}
@@ -4,10 +4,13 @@ package identityschema_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 
 	"github.com/ory/terraform-provider-ory/internal/acctest"
 	"github.com/ory/terraform-provider-ory/internal/testutil"
@@ -67,3 +70,147 @@ func TestAccIdentitySchemaResource_setDefault(t *testing.T) {
 		},
 	})
 }
+
+func TestAccIdentitySchemaResource_versioned(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	schemaID := fmt.Sprintf("tf-test-versioned-%d", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireSchemaTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/versioned.tf.tmpl", map[string]string{"SchemaID": schemaID, "AppURL": testutil.ExampleAppURL, "Title": "Customer"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "version", "1"),
+					resource.TestMatchResourceAttr("ory_identity_schema.test", "id", regexp.MustCompile(`-v1$|^[0-9a-f]+$`)),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "migrated_count", "0"),
+				),
+			},
+			// A content change creates version 2 in place and migrates the identity
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/versioned.tf.tmpl", map[string]string{"SchemaID": schemaID, "AppURL": testutil.ExampleAppURL, "Title": "Customer v2"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "version", "2"),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "migrated_count", "1"),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "migration_failures.%", "0"),
+				),
+			},
+			// Content that is unknown at plan time leaves the version unknown
+			// and still registers a new version once it is known
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/versioned_unknown.tf.tmpl", map[string]string{"SchemaID": schemaID, "AppURL": testutil.ExampleAppURL, "Title": "Customer v3", "Trigger": "1"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ory_identity_schema.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("ory_identity_schema.test", tfjsonpath.New("version")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "version", "3"),
+					resource.TestMatchResourceAttr("ory_identity_schema.test", "id", regexp.MustCompile(`-v3$|^[0-9a-f]+$`)),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "migrated_count", "1"),
+				),
+			},
+			// Unknown content that turns out unchanged keeps the version
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/versioned_unknown.tf.tmpl", map[string]string{"SchemaID": schemaID, "AppURL": testutil.ExampleAppURL, "Title": "Customer v3", "Trigger": "2"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("ory_identity_schema.test", tfjsonpath.New("version")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "version", "3"),
+					resource.TestMatchResourceAttr("ory_identity_schema.test", "id", regexp.MustCompile(`-v3$|^[0-9a-f]+$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentitySchemaResource_compatibilityCheck(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	schemaID := fmt.Sprintf("tf-test-compat-%d", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireSchemaTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/compatibility.tf.tmpl", map[string]string{"SchemaID": schemaID, "AppURL": testutil.ExampleAppURL, "RequirePhone": ""}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "compatibility_check", "error"),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "compatibility_sample_size", "0"),
+				),
+			},
+			// A new required trait is a breaking change and fails the plan
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/compatibility.tf.tmpl", map[string]string{"SchemaID": schemaID, "AppURL": testutil.ExampleAppURL, "RequirePhone": "true"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Breaking Identity Schema Change`),
+			},
+		},
+	})
+}
+
+func TestAccIdentitySchemaResource_preset(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	schemaID := fmt.Sprintf("tf-test-preset-%d", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireSchemaTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/preset.tf.tmpl", map[string]string{"SchemaID": schemaID, "Preset": "email_password"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_identity_schema.test", "id"),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "preset", "email_password"),
+					resource.TestMatchResourceAttr("ory_identity_schema.test", "schema", regexp.MustCompile(`"password":\{"identifier":true\}`)),
+				),
+			},
+			// Switching the preset generates a different schema and recreates the resource
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/preset.tf.tmpl", map[string]string{"SchemaID": schemaID, "Preset": "username"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "preset", "username"),
+					resource.TestMatchResourceAttr("ory_identity_schema.test", "schema", regexp.MustCompile(`"username":`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentitySchemaResource_traits(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	schemaID := fmt.Sprintf("tf-test-traits-%d", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireSchemaTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/traits.tf.tmpl", map[string]string{"SchemaID": schemaID}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_identity_schema.test", "id"),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "traits.#", "2"),
+					resource.TestCheckResourceAttr("ory_identity_schema.test", "traits.1.type", "string"),
+					resource.TestMatchResourceAttr("ory_identity_schema.test", "schema", regexp.MustCompile(`"code":\{"identifier":true,"via":"email"\}`)),
+				),
+			},
+		},
+	})
+}
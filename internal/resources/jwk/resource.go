@@ -2,7 +2,6 @@ package jwk
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -16,6 +15,7 @@ import (
 	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -37,12 +37,12 @@ type JWKResource struct {
 
 // JWKResourceModel describes the resource data model.
 type JWKResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	SetID     types.String `tfsdk:"set_id"`
-	KeyID     types.String `tfsdk:"key_id"`
-	Algorithm types.String `tfsdk:"algorithm"`
-	Use       types.String `tfsdk:"use"`
-	Keys      types.String `tfsdk:"keys"`
+	ID        types.String           `tfsdk:"id"`
+	SetID     types.String           `tfsdk:"set_id"`
+	KeyID     types.String           `tfsdk:"key_id"`
+	Algorithm types.String           `tfsdk:"algorithm"`
+	Use       types.String           `tfsdk:"use"`
+	Keys      helpers.NormalizedJSON `tfsdk:"keys"`
 }
 
 func (r *JWKResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -136,6 +136,7 @@ func (r *JWKResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			},
 			"keys": schema.StringAttribute{
 				Description: "The JSON Web Key Set as a JSON string (public parts only).",
+				CustomType:  helpers.NormalizedJSONType{},
 				Computed:    true,
 				Sensitive:   true,
 			},
@@ -187,9 +188,9 @@ func (r *JWKResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	// Serialize the keys to JSON
 	if len(jwks.Keys) > 0 {
-		keysJSON, err := json.Marshal(jwks)
+		keysJSON, err := helpers.NewNormalizedJSONFromObject(jwks)
 		if err == nil {
-			plan.Keys = types.StringValue(string(keysJSON))
+			plan.Keys = keysJSON
 		}
 	}
 
@@ -220,9 +221,9 @@ func (r *JWKResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	// Serialize the keys to JSON
-	keysJSON, err := json.Marshal(jwks)
+	keysJSON, err := helpers.NewNormalizedJSONFromObject(jwks)
 	if err == nil {
-		state.Keys = types.StringValue(string(keysJSON))
+		state.Keys = keysJSON
 	}
 
 	// Try to extract algorithm and use from the first key
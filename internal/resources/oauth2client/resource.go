@@ -2,7 +2,6 @@ package oauth2client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -16,6 +15,7 @@ import (
 	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -37,30 +37,30 @@ type OAuth2ClientResource struct {
 
 // OAuth2ClientResourceModel describes the resource data model.
 type OAuth2ClientResourceModel struct {
-	ID                      types.String `tfsdk:"id"`
-	ClientID                types.String `tfsdk:"client_id"`
-	ClientSecret            types.String `tfsdk:"client_secret"`
-	ClientName              types.String `tfsdk:"client_name"`
-	GrantTypes              types.List   `tfsdk:"grant_types"`
-	ResponseTypes           types.List   `tfsdk:"response_types"`
-	Scope                   types.String `tfsdk:"scope"`
-	Audience                types.List   `tfsdk:"audience"`
-	RedirectURIs            types.List   `tfsdk:"redirect_uris"`
-	PostLogoutRedirectURIs  types.List   `tfsdk:"post_logout_redirect_uris"`
-	TokenEndpointAuthMethod types.String `tfsdk:"token_endpoint_auth_method"`
-	Metadata                types.String `tfsdk:"metadata"`
-	AllowedCorsOrigins      types.List   `tfsdk:"allowed_cors_origins"`
-	ClientURI               types.String `tfsdk:"client_uri"`
-	LogoURI                 types.String `tfsdk:"logo_uri"`
-	PolicyURI               types.String `tfsdk:"policy_uri"`
-	TosURI                  types.String `tfsdk:"tos_uri"`
-	FrontchannelLogoutURI   types.String `tfsdk:"frontchannel_logout_uri"`
-	BackchannelLogoutURI    types.String `tfsdk:"backchannel_logout_uri"`
-	AccessTokenStrategy     types.String `tfsdk:"access_token_strategy"`
-	SkipConsent             types.Bool   `tfsdk:"skip_consent"`
-	SkipLogoutConsent       types.Bool   `tfsdk:"skip_logout_consent"`
-	SubjectType             types.String `tfsdk:"subject_type"`
-	Contacts                types.List   `tfsdk:"contacts"`
+	ID                      types.String           `tfsdk:"id"`
+	ClientID                types.String           `tfsdk:"client_id"`
+	ClientSecret            types.String           `tfsdk:"client_secret"`
+	ClientName              types.String           `tfsdk:"client_name"`
+	GrantTypes              types.List             `tfsdk:"grant_types"`
+	ResponseTypes           types.List             `tfsdk:"response_types"`
+	Scope                   types.String           `tfsdk:"scope"`
+	Audience                types.List             `tfsdk:"audience"`
+	RedirectURIs            types.List             `tfsdk:"redirect_uris"`
+	PostLogoutRedirectURIs  types.List             `tfsdk:"post_logout_redirect_uris"`
+	TokenEndpointAuthMethod types.String           `tfsdk:"token_endpoint_auth_method"`
+	Metadata                helpers.NormalizedJSON `tfsdk:"metadata"`
+	AllowedCorsOrigins      types.List             `tfsdk:"allowed_cors_origins"`
+	ClientURI               types.String           `tfsdk:"client_uri"`
+	LogoURI                 types.String           `tfsdk:"logo_uri"`
+	PolicyURI               types.String           `tfsdk:"policy_uri"`
+	TosURI                  types.String           `tfsdk:"tos_uri"`
+	FrontchannelLogoutURI   types.String           `tfsdk:"frontchannel_logout_uri"`
+	BackchannelLogoutURI    types.String           `tfsdk:"backchannel_logout_uri"`
+	AccessTokenStrategy     types.String           `tfsdk:"access_token_strategy"`
+	SkipConsent             types.Bool             `tfsdk:"skip_consent"`
+	SkipLogoutConsent       types.Bool             `tfsdk:"skip_logout_consent"`
+	SubjectType             types.String           `tfsdk:"subject_type"`
+	Contacts                types.List             `tfsdk:"contacts"`
 
 	// Per-grant token lifespans
 	AuthorizationCodeGrantAccessTokenLifespan    types.String `tfsdk:"authorization_code_grant_access_token_lifespan"`
@@ -203,6 +203,7 @@ func (r *OAuth2ClientResource) Schema(ctx context.Context, req resource.SchemaRe
 			},
 			"metadata": schema.StringAttribute{
 				Description: "Custom metadata as JSON string.",
+				CustomType:  helpers.NormalizedJSONType{},
 				Optional:    true,
 			},
 			"allowed_cors_origins": schema.ListAttribute{
@@ -423,7 +424,7 @@ func (r *OAuth2ClientResource) Create(ctx context.Context, req resource.CreateRe
 
 	if !plan.Metadata.IsNull() && !plan.Metadata.IsUnknown() {
 		var metadata map[string]interface{}
-		if err := json.Unmarshal([]byte(plan.Metadata.ValueString()), &metadata); err != nil {
+		if err := plan.Metadata.Unmarshal(&metadata); err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Metadata JSON",
 				"Could not parse metadata as JSON: "+err.Error(),
@@ -629,9 +630,9 @@ func (r *OAuth2ClientResource) Read(ctx context.Context, req resource.ReadReques
 	// The API returns {} (empty object) by default, but we want null in Terraform state
 	// when metadata wasn't specified in the config
 	if len(oauthClient.Metadata) > 0 {
-		metadataJSON, err := json.Marshal(oauthClient.Metadata)
+		metadataJSON, err := helpers.NewNormalizedJSONFromObject(oauthClient.Metadata)
 		if err == nil {
-			state.Metadata = types.StringValue(string(metadataJSON))
+			state.Metadata = metadataJSON
 		}
 	}
 
@@ -786,7 +787,7 @@ func (r *OAuth2ClientResource) Update(ctx context.Context, req resource.UpdateRe
 
 	if !plan.Metadata.IsNull() && !plan.Metadata.IsUnknown() {
 		var metadata map[string]interface{}
-		if err := json.Unmarshal([]byte(plan.Metadata.ValueString()), &metadata); err != nil {
+		if err := plan.Metadata.Unmarshal(&metadata); err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid Metadata JSON",
 				"Could not parse metadata as JSON: "+err.Error(),
@@ -6,12 +6,15 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
 )
@@ -40,6 +43,15 @@ type OrganizationResourceModel struct {
 	Domains   types.List   `tfsdk:"domains"`
 	ProjectID types.String `tfsdk:"project_id"`
 	CreatedAt types.String `tfsdk:"created_at"`
+
+	SSOConnections types.List `tfsdk:"sso_connections"`
+}
+
+// ssoConnectionAttrTypes are the attribute types of an sso_connections entry.
+var ssoConnectionAttrTypes = map[string]attr.Type{
+	"provider_id": types.StringType,
+	"protocol":    types.StringType,
+	"label":       types.StringType,
 }
 
 func (r *OrganizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -118,6 +130,29 @@ func (r *OrganizationResource) Schema(ctx context.Context, req resource.SchemaRe
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"sso_connections": schema.ListNestedAttribute{
+				Description: "OIDC and SAML providers bound to this organization through their organization_id, in project configuration order.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"provider_id": schema.StringAttribute{
+							Description: "ID of the provider.",
+							Computed:    true,
+						},
+						"protocol": schema.StringAttribute{
+							Description: "Protocol of the provider: 'oidc' or 'saml'.",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Label of the provider, if set.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -189,6 +224,7 @@ func (r *OrganizationResource) Create(ctx context.Context, req resource.CreateRe
 	plan.ProjectID = types.StringValue(projectID)
 	plan.Label = types.StringValue(org.GetLabel())
 	plan.CreatedAt = types.StringValue(org.CreatedAt.String())
+	plan.SSOConnections = r.readSSOConnections(ctx, projectID, org.GetId(), &resp.Diagnostics)
 
 	// Preserve null state for domains if it was null in the plan
 	// This prevents "inconsistent result after apply" errors when the API
@@ -229,6 +265,7 @@ func (r *OrganizationResource) Read(ctx context.Context, req resource.ReadReques
 	state.Label = types.StringValue(org.GetLabel())
 	state.ProjectID = types.StringValue(projectID)
 	state.CreatedAt = types.StringValue(org.CreatedAt.String())
+	state.SSOConnections = r.readSSOConnections(ctx, projectID, state.ID.ValueString(), &resp.Diagnostics)
 
 	// Preserve null state for domains if it was null in the existing state
 	// This prevents drift when the API returns an empty array
@@ -284,6 +321,11 @@ func (r *OrganizationResource) Update(ctx context.Context, req resource.UpdateRe
 	plan.Label = types.StringValue(org.GetLabel())
 	// Preserve created_at from state - the API may return a slightly different timestamp format
 	plan.CreatedAt = state.CreatedAt
+	// sso_connections is planned from state; it is only unknown for state
+	// written before the attribute existed.
+	if plan.SSOConnections.IsUnknown() {
+		plan.SSOConnections = r.readSSOConnections(ctx, projectID, state.ID.ValueString(), &resp.Diagnostics)
+	}
 
 	// Preserve null state for domains if it was null in the plan
 	if plan.Domains.IsNull() {
@@ -345,3 +387,66 @@ func (r *OrganizationResource) resolveProjectID(tfProjectID types.String) string
 	}
 	return r.client.ProjectID()
 }
+
+// readSSOConnections lists the providers of the project's OIDC and SAML
+// methods that are bound to the organization.
+func (r *OrganizationResource) readSSOConnections(ctx context.Context, projectID, orgID string, diags *diag.Diagnostics) types.List {
+	objectType := types.ObjectType{AttrTypes: ssoConnectionAttrTypes}
+
+	project := r.client.GetCachedProject(projectID)
+	if project == nil {
+		var err error
+		project, err = r.client.GetProject(ctx, projectID)
+		if err != nil {
+			diags.AddError("Error Reading Organization SSO Connections",
+				"Could not read project "+projectID+": "+err.Error())
+			return types.ListNull(objectType)
+		}
+	}
+
+	connections := []attr.Value{}
+	for _, protocol := range []string{"oidc", "saml"} {
+		for _, p := range methodProviders(project, protocol) {
+			if p["organization_id"] != orgID {
+				continue
+			}
+			providerID, _ := p["id"].(string)
+			label := types.StringNull()
+			if l, ok := p["label"].(string); ok && l != "" {
+				label = types.StringValue(l)
+			}
+			connection, d := types.ObjectValue(ssoConnectionAttrTypes, map[string]attr.Value{
+				"provider_id": types.StringValue(providerID),
+				"protocol":    types.StringValue(protocol),
+				"label":       label,
+			})
+			diags.Append(d...)
+			connections = append(connections, connection)
+		}
+	}
+
+	list, d := types.ListValue(objectType, connections)
+	diags.Append(d...)
+	return list
+}
+
+// methodProviders returns the providers configured for a self-service
+// method, e.g. selfservice.methods.oidc.config.providers.
+func methodProviders(project *ory.Project, method string) []map[string]interface{} {
+	if project.Services.Identity == nil {
+		return nil
+	}
+	selfservice, _ := project.Services.Identity.Config["selfservice"].(map[string]interface{})
+	methods, _ := selfservice["methods"].(map[string]interface{})
+	methodConfig, _ := methods[method].(map[string]interface{})
+	config, _ := methodConfig["config"].(map[string]interface{})
+	providers, _ := config["providers"].([]interface{})
+
+	result := make([]map[string]interface{}, 0, len(providers))
+	for _, p := range providers {
+		if pm, ok := p.(map[string]interface{}); ok {
+			result = append(result, pm)
+		}
+	}
+	return result
+}
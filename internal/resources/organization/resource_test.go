@@ -97,3 +97,34 @@ func TestAccOrganizationResource_withDomains(t *testing.T) {
 		},
 	})
 }
+
+func TestAccOrganizationResource_ssoConnection(t *testing.T) {
+	vars := map[string]string{"Label": "Org with SSO", "Domain": "sso." + testutil.ExampleEmailDomain}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckB2B(t)
+			acctest.RequireSocialProviderTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Bind a social provider to the organization
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/sso_connection.tf.tmpl", vars),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ory_social_provider.test", "organization_id", "ory_organization.test", "id"),
+				),
+			},
+			// The connection is read back on the organization
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_organization.test", "sso_connections.#", "1"),
+					resource.TestCheckResourceAttr("ory_organization.test", "sso_connections.0.provider_id", "test-org-sso"),
+					resource.TestCheckResourceAttr("ory_organization.test", "sso_connections.0.protocol", "oidc"),
+					resource.TestCheckResourceAttr("ory_organization.test", "sso_connections.0.label", "Org SSO"),
+				),
+			},
+		},
+	})
+}
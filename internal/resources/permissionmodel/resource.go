@@ -0,0 +1,362 @@
+package permissionmodel
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+const namespacesConfigPath = "/services/permission/config/namespaces"
+
+// previousNamespacesKey is the private state key holding the namespaces
+// config that was in place before the resource was created.
+const previousNamespacesKey = "previous_namespaces"
+
+var (
+	_ resource.Resource                   = &PermissionModelResource{}
+	_ resource.ResourceWithConfigure      = &PermissionModelResource{}
+	_ resource.ResourceWithImportState    = &PermissionModelResource{}
+	_ resource.ResourceWithValidateConfig = &PermissionModelResource{}
+	_ resource.ResourceWithModifyPlan     = &PermissionModelResource{}
+)
+
+func NewResource() resource.Resource {
+	return &PermissionModelResource{}
+}
+
+type PermissionModelResource struct {
+	client *client.OryClient
+}
+
+type PermissionModelResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	OPL        types.String `tfsdk:"opl"`
+	Namespaces types.List   `tfsdk:"namespaces"`
+}
+
+func (r *PermissionModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_model"
+}
+
+const permissionModelMarkdownDescription = `
+Manages the Ory Permission Language (OPL) model of an Ory Network project.
+
+The OPL document defines the Keto namespaces, their relations, and their permissions as
+TypeScript classes. It is validated locally when planning: syntax errors and references to
+undeclared namespaces or relations are reported with line numbers before anything is uploaded.
+
+Do not combine it with ` + "`keto_namespaces`" + ` on ` + "`ory_project_config`" + `: both write the same project
+setting and would overwrite each other on every apply.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "ory_permission_model" "main" {
+  opl = file("${path.module}/namespaces.ts")
+}
+` + "```" + `
+
+## Import
+
+Import using the project ID:
+
+` + "```shell" + `
+terraform import ory_permission_model.main <project-id>
+` + "```" + `
+`
+
+func (r *PermissionModelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Manages the Ory Permission Language (OPL) model of an Ory Network project.",
+		MarkdownDescription: permissionModelMarkdownDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (same as project_id).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Project ID. If not set, uses provider's project_id.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"opl": schema.StringAttribute{
+				Description: "The OPL document (TypeScript) declaring the namespaces, relations, and permissions.",
+				Required:    true,
+			},
+			"namespaces": schema.ListAttribute{
+				Description: "Names of the namespaces declared in the OPL document, in declaration order.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *PermissionModelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	r.client = oryClient
+}
+
+func (r *PermissionModelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var opl types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("opl"), &opl)...)
+	if resp.Diagnostics.HasError() || opl.IsNull() || opl.IsUnknown() {
+		return
+	}
+
+	_, errs := helpers.ParseOPL(opl.ValueString())
+	for _, e := range errs {
+		resp.Diagnostics.AddAttributeError(path.Root("opl"), "Invalid Permission Model", e.Error())
+	}
+}
+
+// ModifyPlan fills in the declared namespaces so they are known at plan time.
+func (r *PermissionModelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var opl types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("opl"), &opl)...)
+	if resp.Diagnostics.HasError() || opl.IsUnknown() {
+		return
+	}
+
+	// Parse errors are already reported by ValidateConfig.
+	namespaces, diags := namespacesFromOPL(ctx, opl.ValueString())
+	if diags.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("namespaces"), namespaces)...)
+}
+
+func encodeOPL(content string) string {
+	return "base64://" + base64.StdEncoding.EncodeToString([]byte(content))
+}
+
+// namespacesFromOPL parses an OPL document and returns the declared namespace
+// names as a list value.
+func namespacesFromOPL(ctx context.Context, opl string) (types.List, diag.Diagnostics) {
+	parsed, errs := helpers.ParseOPL(opl)
+	if len(errs) > 0 {
+		var diags diag.Diagnostics
+		for _, e := range errs {
+			diags.AddAttributeError(path.Root("opl"), "Invalid Permission Model", e.Error())
+		}
+		return types.ListNull(types.StringType), diags
+	}
+
+	names := make([]string, 0, len(parsed))
+	for _, ns := range parsed {
+		names = append(names, ns.Name)
+	}
+	return types.ListValueFrom(ctx, types.StringType, names)
+}
+
+func (r *PermissionModelResource) apply(ctx context.Context, plan *PermissionModelResourceModel, diags *diag.Diagnostics) {
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), diags)
+	if diags.HasError() {
+		return
+	}
+
+	namespaces, d := namespacesFromOPL(ctx, plan.OPL.ValueString())
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	patches := []ory.JsonPatch{{
+		Op:   "add",
+		Path: namespacesConfigPath,
+		Value: map[string]interface{}{
+			"location": encodeOPL(plan.OPL.ValueString()),
+		},
+	}}
+
+	if _, err := r.client.PatchProject(ctx, projectID, patches); err != nil {
+		diags.AddError("Error Updating Permission Model", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(projectID)
+	plan.ProjectID = types.StringValue(projectID)
+	plan.Namespaces = namespaces
+}
+
+func (r *PermissionModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PermissionModelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remember the namespaces config this resource replaces, so Delete
+	// restores it instead of removing namespaces configured before.
+	project, err := r.client.GetProject(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Permission Model", err.Error())
+		return
+	}
+	var previous interface{} = []interface{}{}
+	if project.Services.Permission != nil {
+		if ns, ok := project.Services.Permission.Config["namespaces"]; ok && ns != nil {
+			previous = ns
+		}
+	}
+	previousJSON, err := json.Marshal(previous)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Permission Model", err.Error())
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, previousNamespacesKey, previousJSON)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *PermissionModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PermissionModelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(state.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.GetProject(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Permission Model", err.Error())
+		return
+	}
+
+	// The namespaces config is either a list of names (managed by
+	// ory_project_config.keto_namespaces) or an OPL location. Anything other
+	// than an OPL location means the model is no longer configured.
+	var location string
+	if project.Services.Permission != nil {
+		if ns, ok := project.Services.Permission.Config["namespaces"].(map[string]interface{}); ok {
+			location, _ = ns["location"].(string)
+		}
+	}
+	if location == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Other locations (e.g. URLs) cannot be read back; keep the configured OPL
+	if strings.HasPrefix(location, "base64://") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(location, "base64://"))
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Permission Model",
+				fmt.Sprintf("Could not decode OPL location: %s", err))
+			return
+		}
+		state.OPL = types.StringValue(string(decoded))
+	}
+
+	// Drift may introduce an OPL document this parser rejects; keep the
+	// previous namespaces instead of failing the refresh.
+	if namespaces, diags := namespacesFromOPL(ctx, state.OPL.ValueString()); !diags.HasError() {
+		state.Namespaces = namespaces
+	}
+
+	state.ID = types.StringValue(projectID)
+	state.ProjectID = types.StringValue(projectID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *PermissionModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PermissionModelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *PermissionModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PermissionModelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Restore the namespaces config from before Create. Imported resources
+	// have none recorded and reset the project to no namespaces.
+	var previous interface{} = []interface{}{}
+	previousJSON, diags := req.Private.GetKey(ctx, previousNamespacesKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(previousJSON) > 0 {
+		if err := json.Unmarshal(previousJSON, &previous); err != nil {
+			resp.Diagnostics.AddError("Error Deleting Permission Model", err.Error())
+			return
+		}
+	}
+
+	patches := []ory.JsonPatch{{
+		Op:    "add",
+		Path:  namespacesConfigPath,
+		Value: previous,
+	}}
+
+	if _, err := r.client.PatchProject(ctx, state.ProjectID.ValueString(), patches); err != nil {
+		resp.Diagnostics.AddError("Error Deleting Permission Model", err.Error())
+		return
+	}
+}
+
+func (r *PermissionModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), req.ID)...)
+}
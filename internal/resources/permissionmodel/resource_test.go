@@ -0,0 +1,62 @@
+//go:build acceptance
+
+package permissionmodel_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccPermissionModelResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireKetoTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"DocumentNamespace": "Document"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ory_permission_model.test", "id"),
+					resource.TestCheckResourceAttr("ory_permission_model.test", "namespaces.#", "3"),
+					resource.TestCheckResourceAttr("ory_permission_model.test", "namespaces.0", "User"),
+					resource.TestCheckResourceAttr("ory_permission_model.test", "namespaces.2", "Document"),
+				),
+			},
+			// ImportState
+			{
+				ResourceName:      "ory_permission_model.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"DocumentNamespace": "File"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_permission_model.test", "namespaces.#", "3"),
+					resource.TestCheckResourceAttr("ory_permission_model.test", "namespaces.2", "File"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPermissionModelResource_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid.tf.tmpl", nil),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`line 6, column 13: unknown namespace "Usr"`),
+			},
+		},
+	})
+}
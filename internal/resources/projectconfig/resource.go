@@ -279,7 +279,7 @@ func (r *ProjectConfigResource) Schema(ctx context.Context, req resource.SchemaR
 			"keto_namespaces": schema.ListAttribute{
 				Description: "List of Keto namespace names to configure for Ory Permissions. " +
 					"Namespaces define the types of resources in your permission model (e.g., 'documents', 'folders'). " +
-					"Each namespace name must be unique.",
+					"Each namespace name must be unique. Do not use together with ory_permission_model, which manages the same setting.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
@@ -539,8 +539,11 @@ func (r *ProjectConfigResource) Schema(ctx context.Context, req resource.SchemaR
 							},
 						},
 						"claims_mapper_url": schema.StringAttribute{
-							Description: "Jsonnet claims mapper URL. Supports base64:// and https:// schemes.",
+							Description: "Jsonnet claims mapper URL. Supports base64:// and https:// schemes. base64:// mappers are checked for syntax errors at plan time.",
 							Optional:    true,
+							Validators: []validator.String{
+								helpers.JsonnetValidator(),
+							},
 						},
 						"subject_source": schema.StringAttribute{
 							Description: "Subject source for the JWT: 'id' (default) or 'external_id'.",
@@ -645,8 +648,11 @@ func courierHTTPRequestConfigSchemaAttr(description string) schema.SingleNestedA
 				ElementType: types.StringType,
 			},
 			"body": schema.StringAttribute{
-				Description: "Request body template. Supports base64:// scheme for Jsonnet templates.",
+				Description: "Request body template. Supports base64:// scheme for Jsonnet templates, which are checked for syntax errors at plan time.",
 				Optional:    true,
+				Validators: []validator.String{
+					helpers.JsonnetValidator(),
+				},
 			},
 			"auth": schema.SingleNestedAttribute{
 				Description: "Authentication configuration for the HTTP request.",
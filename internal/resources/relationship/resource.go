@@ -3,24 +3,28 @@ package relationship
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &RelationshipResource{}
-	_ resource.ResourceWithConfigure   = &RelationshipResource{}
-	_ resource.ResourceWithImportState = &RelationshipResource{}
+	_ resource.Resource                   = &RelationshipResource{}
+	_ resource.ResourceWithConfigure      = &RelationshipResource{}
+	_ resource.ResourceWithImportState    = &RelationshipResource{}
+	_ resource.ResourceWithValidateConfig = &RelationshipResource{}
+	_ resource.ResourceWithModifyPlan     = &RelationshipResource{}
 )
 
 // NewResource returns a new Relationship resource.
@@ -36,6 +40,7 @@ type RelationshipResource struct {
 // RelationshipResourceModel describes the resource data model.
 type RelationshipResourceModel struct {
 	ID                  types.String `tfsdk:"id"`
+	Tuple               types.String `tfsdk:"tuple"`
 	Namespace           types.String `tfsdk:"namespace"`
 	Object              types.String `tfsdk:"object"`
 	Relation            types.String `tfsdk:"relation"`
@@ -94,6 +99,17 @@ resource "ory_relationship" "editors_can_view" {
 This means: anyone who is an "editor" of "folder-789" in the "folders" namespace
 is automatically a "viewer" of "doc-123" in the "documents" namespace.
 
+### Tuple Syntax
+
+Alternatively, write the whole relationship as a single Zanzibar-style string.
+The individual attributes are then computed from it:
+
+` + "```hcl" + `
+resource "ory_relationship" "admins_can_view" {
+  tuple = "documents:doc-123#viewer@(groups:admins#members)"
+}
+` + "```" + `
+
 ## Note
 
 You must configure Ory Keto namespaces and permissions in your project
@@ -113,54 +129,58 @@ func (r *RelationshipResource) Schema(ctx context.Context, req resource.SchemaRe
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"namespace": schema.StringAttribute{
-				Description: "The namespace of the relationship (e.g., 'documents', 'folders').",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+			"tuple": schema.StringAttribute{
+				Description: "The relationship in Zanzibar notation: namespace:object#relation@subject, where subject is a subject ID " +
+					"(user-1), a subject set (User:alice), or a subject set with a relation ((Group:admins#members)). " +
+					"Mutually exclusive with the individual attributes, which are computed from it.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("namespace")),
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("object"),
+						path.MatchRoot("relation"),
+						path.MatchRoot("subject_id"),
+						path.MatchRoot("subject_set_namespace"),
+						path.MatchRoot("subject_set_object"),
+						path.MatchRoot("subject_set_relation"),
+					),
 				},
 			},
+			"namespace": schema.StringAttribute{
+				Description: "The namespace of the relationship (e.g., 'documents', 'folders'). Required unless tuple is set.",
+				Optional:    true,
+				Computed:    true,
+			},
 			"object": schema.StringAttribute{
-				Description: "The object ID in the namespace.",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "The object ID in the namespace. Required unless tuple is set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"relation": schema.StringAttribute{
-				Description: "The relation type (e.g., 'viewer', 'editor', 'owner').",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "The relation type (e.g., 'viewer', 'editor', 'owner'). Required unless tuple is set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"subject_id": schema.StringAttribute{
 				Description: "The subject ID (user ID). Mutually exclusive with subject_set_* attributes.",
 				Optional:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Computed:    true,
 			},
 			"subject_set_namespace": schema.StringAttribute{
 				Description: "The namespace for a subject set. Use with subject_set_object and subject_set_relation.",
 				Optional:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Computed:    true,
 			},
 			"subject_set_object": schema.StringAttribute{
 				Description: "The object ID for a subject set.",
 				Optional:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Computed:    true,
 			},
 			"subject_set_relation": schema.StringAttribute{
 				Description: "The relation for a subject set.",
 				Optional:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Computed:    true,
 			},
 		},
 	}
@@ -183,63 +203,147 @@ func (r *RelationshipResource) Configure(ctx context.Context, req resource.Confi
 	r.client = oryClient
 }
 
-func (r *RelationshipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan RelationshipResourceModel
+func (r *RelationshipResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config RelationshipResourceModel
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Validate that either subject_id or subject_set is provided, not both
-	hasSubjectID := !plan.SubjectID.IsNull() && !plan.SubjectID.IsUnknown()
-	hasSubjectSet := (!plan.SubjectSetNamespace.IsNull() && !plan.SubjectSetNamespace.IsUnknown()) ||
-		(!plan.SubjectSetObject.IsNull() && !plan.SubjectSetObject.IsUnknown()) ||
-		(!plan.SubjectSetRelation.IsNull() && !plan.SubjectSetRelation.IsUnknown())
+	if !config.Tuple.IsNull() {
+		if config.Tuple.IsUnknown() {
+			return
+		}
+		if _, err := helpers.ParseRelationTuple(config.Tuple.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("tuple"), "Invalid Relation Tuple", err.Error())
+		}
+		return
+	}
+
+	if config.Object.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("object"), "Missing Attribute",
+			"object is required unless tuple is set.")
+	}
+	if config.Relation.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("relation"), "Missing Attribute",
+			"relation is required unless tuple is set.")
+	}
 
-	if hasSubjectID && hasSubjectSet {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			"Cannot specify both subject_id and subject_set_* attributes. Use one or the other.",
-		)
+	subjectSet := []types.String{config.SubjectSetNamespace, config.SubjectSetObject, config.SubjectSetRelation}
+	setCount := 0
+	for _, v := range subjectSet {
+		if !v.IsNull() {
+			setCount++
+		}
+	}
+
+	switch {
+	case !config.SubjectID.IsNull() && setCount > 0:
+		resp.Diagnostics.AddError("Invalid Configuration",
+			"Cannot specify both subject_id and subject_set_* attributes. Use one or the other.")
+	case config.SubjectID.IsNull() && setCount == 0:
+		resp.Diagnostics.AddError("Invalid Configuration",
+			"Must specify either subject_id or all subject_set_* attributes.")
+	case setCount > 0 && setCount < len(subjectSet):
+		resp.Diagnostics.AddError("Invalid Configuration",
+			"When using subject_set, all of subject_set_namespace, subject_set_object, and subject_set_relation must be provided.")
+	}
+}
+
+// ModifyPlan keeps tuple and the individual attributes in sync, so both are
+// known at plan time regardless of which form is configured. It also decides
+// replacement by comparing the parsed relationships, so a different spelling
+// of the same tuple, or switching between tuple and the individual
+// attributes, is updated in place.
+func (r *RelationshipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	if !hasSubjectID && !hasSubjectSet {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			"Must specify either subject_id or all subject_set_* attributes.",
-		)
+	var config, plan RelationshipResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if hasSubjectSet {
-		// Validate all subject_set fields are provided
-		if plan.SubjectSetNamespace.IsNull() || plan.SubjectSetObject.IsNull() || plan.SubjectSetRelation.IsNull() {
-			resp.Diagnostics.AddError(
-				"Invalid Configuration",
-				"When using subject_set, all of subject_set_namespace, subject_set_object, and subject_set_relation must be provided.",
-			)
+	var state *RelationshipResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &RelationshipResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
-	body := ory.CreateRelationshipBody{
-		Namespace: ory.PtrString(plan.Namespace.ValueString()),
-		Object:    ory.PtrString(plan.Object.ValueString()),
-		Relation:  ory.PtrString(plan.Relation.ValueString()),
+	var rel ory.Relationship
+	if !config.Tuple.IsNull() {
+		if config.Tuple.IsUnknown() {
+			// The relationship cannot be compared until apply
+			if state != nil {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("tuple"))
+			}
+			return
+		}
+		parsed, err := helpers.ParseRelationTuple(config.Tuple.ValueString())
+		if err != nil {
+			// Already reported by ValidateConfig
+			return
+		}
+		rel = parsed
+	} else {
+		parsed, ok := relationshipFromAttributes(&config)
+		if !ok {
+			if state != nil {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("tuple"))
+			}
+			return
+		}
+		rel = parsed
 	}
 
-	if hasSubjectID {
-		body.SubjectId = ory.PtrString(plan.SubjectID.ValueString())
-	} else {
-		body.SubjectSet = &ory.SubjectSet{
-			Namespace: plan.SubjectSetNamespace.ValueString(),
-			Object:    plan.SubjectSetObject.ValueString(),
-			Relation:  plan.SubjectSetRelation.ValueString(),
+	tuple := helpers.FormatRelationTuple(rel)
+	if !config.Tuple.IsNull() {
+		tuple = config.Tuple.ValueString()
+	}
+
+	if state != nil {
+		prior, _ := relationshipFromAttributes(state)
+		if helpers.FormatRelationTuple(prior) != helpers.FormatRelationTuple(rel) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("tuple"))
+		} else if config.Tuple.IsNull() && !state.Tuple.IsNull() {
+			// Same relationship, keep the spelling that is already in state
+			tuple = state.Tuple.ValueString()
 		}
 	}
 
+	plan.Tuple = types.StringValue(tuple)
+	setRelationshipAttributes(&plan, rel)
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *RelationshipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RelationshipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Subject exclusivity is enforced by ValidateConfig, and ModifyPlan fills
+	// in the attributes when the tuple syntax is used.
+	want, _ := relationshipFromAttributes(&plan)
+
+	body := ory.CreateRelationshipBody{
+		Namespace:  ory.PtrString(want.Namespace),
+		Object:     ory.PtrString(want.Object),
+		Relation:   ory.PtrString(want.Relation),
+		SubjectId:  want.SubjectId,
+		SubjectSet: want.SubjectSet,
+	}
+
 	rel, err := r.client.CreateRelationship(ctx, body)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -298,15 +402,27 @@ func (r *RelationshipResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	// States created before the tuple attribute existed
+	if state.Tuple.IsNull() {
+		rel, _ := relationshipFromAttributes(&state)
+		state.Tuple = types.StringValue(helpers.FormatRelationTuple(rel))
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *RelationshipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Relationships are immutable - all changes require replacement
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Relationships cannot be updated. Changes require resource replacement.",
-	)
+	// Relationships are immutable - any change to the relationship itself
+	// requires replacement. The only in-place update is a different spelling
+	// of the same tuple (e.g., adding parentheses around a subject set).
+	var plan RelationshipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func (r *RelationshipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -317,16 +433,13 @@ func (r *RelationshipResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	var subjectID *string
-	if !state.SubjectID.IsNull() && !state.SubjectID.IsUnknown() {
-		s := state.SubjectID.ValueString()
-		subjectID = &s
-	}
-
-	object := state.Object.ValueString()
-	relation := state.Relation.ValueString()
-
-	err := r.client.DeleteRelationships(ctx, state.Namespace.ValueString(), &object, &relation, subjectID)
+	// Delete exactly this tuple. A query-based delete without a subject ID
+	// would also remove every other subject with the same object and relation.
+	rel, _ := relationshipFromAttributes(&state)
+	err := r.client.PatchRelationships(ctx, []ory.RelationshipPatch{{
+		Action:        ory.PtrString("delete"),
+		RelationTuple: &rel,
+	}})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Relationship",
@@ -337,80 +450,74 @@ func (r *RelationshipResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 // ImportState imports an existing relationship into Terraform state.
-// Import ID format: namespace:object#relation@subject
+// Import ID format is the tuple syntax: namespace:object#relation@subject
 // Where subject is either:
 // - A simple subject_id (e.g., "user-456")
-// - A subject_set in format "namespace:object#relation" (e.g., "folders:folder-789#editor")
+// - A subject set in format "namespace:object#relation", optionally in parentheses
 //
 // Examples:
 // - Simple: documents:doc-123#viewer@user-456
-// - Subject set: documents:doc-123#viewer@folders:folder-789#editor
+// - Subject set: documents:doc-123#viewer@(folders:folder-789#editor)
 func (r *RelationshipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id := req.ID
-
-	// Parse: namespace:object#relation@subject
-	// First split by @ to get subject
-	atIdx := strings.LastIndex(id, "@")
-	if atIdx == -1 {
+	rel, err := helpers.ParseRelationTuple(req.ID)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			"Import ID must be in format: namespace:object#relation@subject. Missing '@' separator.",
+			"Import ID must be in format: namespace:object#relation@subject. "+err.Error(),
 		)
 		return
 	}
 
-	prefix := id[:atIdx]
-	subject := id[atIdx+1:]
-
-	// Parse prefix: namespace:object#relation
-	hashIdx := strings.LastIndex(prefix, "#")
-	if hashIdx == -1 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Import ID must be in format: namespace:object#relation@subject. Missing '#' separator.",
-		)
-		return
-	}
+	var state RelationshipResourceModel
+	state.ID = types.StringValue(generateRelationshipID(&rel))
+	state.Tuple = types.StringValue(req.ID)
+	setRelationshipAttributes(&state, rel)
 
-	namespaceObject := prefix[:hashIdx]
-	relation := prefix[hashIdx+1:]
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-	// Parse namespaceObject: namespace:object
-	colonIdx := strings.Index(namespaceObject, ":")
-	if colonIdx == -1 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Import ID must be in format: namespace:object#relation@subject. Missing ':' separator in namespace:object.",
-		)
-		return
+// relationshipFromAttributes builds a relationship from the individual
+// attributes. It returns false if any of them is unknown.
+func relationshipFromAttributes(m *RelationshipResourceModel) (ory.Relationship, bool) {
+	for _, v := range []types.String{
+		m.Namespace, m.Object, m.Relation,
+		m.SubjectID, m.SubjectSetNamespace, m.SubjectSetObject, m.SubjectSetRelation,
+	} {
+		if v.IsUnknown() {
+			return ory.Relationship{}, false
+		}
 	}
 
-	namespace := namespaceObject[:colonIdx]
-	object := namespaceObject[colonIdx+1:]
-
-	// Set the basic attributes
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object"), object)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("relation"), relation)...)
-
-	// Check if subject is a subject_set (contains : and #) or simple subject_id
-	subjectColonIdx := strings.Index(subject, ":")
-	subjectHashIdx := strings.Index(subject, "#")
-	if subjectColonIdx > 0 && subjectHashIdx > subjectColonIdx {
-		// Subject set format: namespace:object#relation
-		subjectNamespaceObject := subject[:subjectHashIdx]
-		subjectRelation := subject[subjectHashIdx+1:]
-
-		subjectNamespace := subjectNamespaceObject[:subjectColonIdx]
-		subjectObject := subjectNamespaceObject[subjectColonIdx+1:]
-
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subject_set_namespace"), subjectNamespace)...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subject_set_object"), subjectObject)...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subject_set_relation"), subjectRelation)...)
+	rel := ory.Relationship{
+		Namespace: m.Namespace.ValueString(),
+		Object:    m.Object.ValueString(),
+		Relation:  m.Relation.ValueString(),
+	}
+	if !m.SubjectID.IsNull() {
+		rel.SubjectId = ory.PtrString(m.SubjectID.ValueString())
 	} else {
-		// Simple subject_id
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subject_id"), subject)...)
+		rel.SubjectSet = &ory.SubjectSet{
+			Namespace: m.SubjectSetNamespace.ValueString(),
+			Object:    m.SubjectSetObject.ValueString(),
+			Relation:  m.SubjectSetRelation.ValueString(),
+		}
+	}
+	return rel, true
+}
+
+// setRelationshipAttributes sets the individual attributes from a relationship.
+func setRelationshipAttributes(m *RelationshipResourceModel, rel ory.Relationship) {
+	m.Namespace = types.StringValue(rel.Namespace)
+	m.Object = types.StringValue(rel.Object)
+	m.Relation = types.StringValue(rel.Relation)
+	m.SubjectID = types.StringPointerValue(rel.SubjectId)
+	m.SubjectSetNamespace = types.StringNull()
+	m.SubjectSetObject = types.StringNull()
+	m.SubjectSetRelation = types.StringNull()
+	if rel.SubjectSet != nil {
+		m.SubjectSetNamespace = types.StringValue(rel.SubjectSet.Namespace)
+		m.SubjectSetObject = types.StringValue(rel.SubjectSet.Object)
+		m.SubjectSetRelation = types.StringValue(rel.SubjectSet.Relation)
 	}
 }
 
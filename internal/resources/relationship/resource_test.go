@@ -3,9 +3,11 @@
 package relationship_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 
 	"github.com/ory/terraform-provider-ory/internal/acctest"
 )
@@ -26,6 +28,7 @@ func TestAccRelationshipResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("ory_relationship.test", "object", "doc-123"),
 					resource.TestCheckResourceAttr("ory_relationship.test", "relation", "viewer"),
 					resource.TestCheckResourceAttr("ory_relationship.test", "subject_id", "user-456"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "tuple", "documents:doc-123#viewer@user-456"),
 				),
 			},
 			// Import using the composite ID format: namespace:object#relation@subject_id
@@ -35,6 +38,91 @@ func TestAccRelationshipResource_basic(t *testing.T) {
 				ImportStateId:     "documents:doc-123#viewer@user-456",
 				ImportStateVerify: true,
 			},
+			// Switching to the tuple syntax for the same relationship is a no-op
+			{
+				Config:   acctest.LoadTestConfig(t, "testdata/tuple.tf.tmpl", map[string]string{"Tuple": "documents:doc-123#viewer@user-456"}),
+				PlanOnly: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccRelationshipResource_tuple(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireKetoTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/tuple.tf.tmpl", map[string]string{"Tuple": "documents:doc-tuple#viewer@(groups:admins#members)"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_relationship.test", "namespace", "documents"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "object", "doc-tuple"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "relation", "viewer"),
+					resource.TestCheckNoResourceAttr("ory_relationship.test", "subject_id"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "subject_set_namespace", "groups"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "subject_set_object", "admins"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "subject_set_relation", "members"),
+				),
+			},
+			// Import using the same tuple string
+			{
+				ResourceName:      "ory_relationship.test",
+				ImportState:       true,
+				ImportStateId:     "documents:doc-tuple#viewer@(groups:admins#members)",
+				ImportStateVerify: true,
+			},
+			// A different spelling of the same tuple is updated in place
+			{
+				Config:             acctest.LoadTestConfig(t, "testdata/tuple.tf.tmpl", map[string]string{"Tuple": "documents:doc-tuple#viewer@groups:admins#members"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ory_relationship.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/tuple.tf.tmpl", map[string]string{"Tuple": "documents:doc-tuple#viewer@groups:admins#members"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ory_relationship.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ory_relationship.test", "tuple", "documents:doc-tuple#viewer@groups:admins#members"),
+			},
+			// Subject set without a relation
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/tuple.tf.tmpl", map[string]string{"Tuple": "groups:admins#members@users:alice"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_relationship.test", "namespace", "groups"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "subject_set_namespace", "users"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "subject_set_object", "alice"),
+					resource.TestCheckResourceAttr("ory_relationship.test", "subject_set_relation", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRelationshipResource_invalidTuple(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/tuple.tf.tmpl", map[string]string{"Tuple": "documents:doc-123#viewer"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`missing '@' before the subject`),
+			},
 		},
 	})
 }
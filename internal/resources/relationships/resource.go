@@ -0,0 +1,691 @@
+package relationships
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+// patchBatchSize is the maximum number of inserts and deletes sent in a
+// single PatchRelationships call.
+const patchBatchSize = 100
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &RelationshipsResource{}
+	_ resource.ResourceWithConfigure      = &RelationshipsResource{}
+	_ resource.ResourceWithImportState    = &RelationshipsResource{}
+	_ resource.ResourceWithModifyPlan     = &RelationshipsResource{}
+	_ resource.ResourceWithValidateConfig = &RelationshipsResource{}
+)
+
+// NewResource returns a new Relationships resource.
+func NewResource() resource.Resource {
+	return &RelationshipsResource{}
+}
+
+// RelationshipsResource defines the resource implementation.
+type RelationshipsResource struct {
+	client *client.OryClient
+}
+
+// RelationshipsResourceModel describes the resource data model.
+type RelationshipsResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Namespace types.String `tfsdk:"namespace"`
+	Object    types.String `tfsdk:"object"`
+	Exclusive types.Bool   `tfsdk:"exclusive"`
+	Tuples    types.Set    `tfsdk:"tuples"`
+}
+
+// TupleModel describes a single relationship tuple within the scope.
+type TupleModel struct {
+	Object              types.String `tfsdk:"object"`
+	Relation            types.String `tfsdk:"relation"`
+	SubjectID           types.String `tfsdk:"subject_id"`
+	SubjectSetNamespace types.String `tfsdk:"subject_set_namespace"`
+	SubjectSetObject    types.String `tfsdk:"subject_set_object"`
+	SubjectSetRelation  types.String `tfsdk:"subject_set_relation"`
+}
+
+// tupleAttrTypes are the attribute types of a TupleModel object.
+var tupleAttrTypes = map[string]attr.Type{
+	"object":                types.StringType,
+	"relation":              types.StringType,
+	"subject_id":            types.StringType,
+	"subject_set_namespace": types.StringType,
+	"subject_set_object":    types.StringType,
+	"subject_set_relation":  types.StringType,
+}
+
+func (r *RelationshipsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_relationships"
+}
+
+const relationshipsMarkdownDescription = `
+Manages a set of Ory Keto relationship tuples within a namespace (and optionally a single object).
+
+Use this resource instead of many ` + "`ory_relationship`" + ` resources when managing large
+numbers of tuples. Changes are computed as a diff between the declared and actual tuples and
+applied with batched ` + "`PATCH /admin/relation-tuples`" + ` calls, and a refresh lists the
+scope once instead of querying every tuple individually.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "ory_relationships" "engineering" {
+  namespace = "groups"
+  object    = "engineering"
+  exclusive = true
+
+  tuples = [
+    { relation = "members", subject_id = "user-alice" },
+    { relation = "members", subject_id = "user-bob" },
+    {
+      relation              = "members"
+      subject_set_namespace = "groups"
+      subject_set_object    = "platform"
+      subject_set_relation  = "members"
+    },
+  ]
+}
+` + "```" + `
+
+## Import
+
+Import using the scope, either ` + "`namespace`" + ` or ` + "`namespace:object`" + `:
+
+` + "```shell" + `
+terraform import ory_relationships.engineering "groups:engineering"
+` + "```" + `
+`
+
+func (r *RelationshipsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	subjectSetPaths := []path.Expression{
+		path.MatchRelative().AtParent().AtName("subject_set_namespace"),
+		path.MatchRelative().AtParent().AtName("subject_set_object"),
+		path.MatchRelative().AtParent().AtName("subject_set_relation"),
+	}
+
+	resp.Schema = schema.Schema{
+		Description:         "Manages a set of Ory Keto relationship tuples within a namespace/object scope.",
+		MarkdownDescription: relationshipsMarkdownDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal Terraform ID (namespace or namespace:object).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description: "The namespace of all tuples managed by this resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object": schema.StringAttribute{
+				Description: "Restrict the scope to a single object. When set, tuples must not set their own object.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"exclusive": schema.BoolAttribute{
+				Description: "Delete tuples in the scope that are not declared in this resource (default: false). When false, tuples created outside Terraform are left untouched.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"tuples": schema.SetNestedAttribute{
+				Description: "The relationship tuples to manage.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"object": schema.StringAttribute{
+							Description: "The object ID. Required unless the resource-level object is set.",
+							Optional:    true,
+						},
+						"relation": schema.StringAttribute{
+							Description: "The relation type (e.g., 'viewer', 'members').",
+							Required:    true,
+						},
+						"subject_id": schema.StringAttribute{
+							Description: "The subject ID (user ID). Mutually exclusive with subject_set_* attributes.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("subject_set_namespace")),
+							},
+						},
+						"subject_set_namespace": schema.StringAttribute{
+							Description: "The namespace for a subject set. Use with subject_set_object and subject_set_relation.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(subjectSetPaths...),
+							},
+						},
+						"subject_set_object": schema.StringAttribute{
+							Description: "The object ID for a subject set.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(subjectSetPaths...),
+							},
+						},
+						"subject_set_relation": schema.StringAttribute{
+							Description: "The relation for a subject set.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(subjectSetPaths...),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *RelationshipsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = oryClient
+}
+
+func (r *RelationshipsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config RelationshipsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Object.IsUnknown() || config.Tuples.IsNull() || config.Tuples.IsUnknown() {
+		return
+	}
+	hasScopeObject := !config.Object.IsNull()
+
+	for _, elem := range config.Tuples.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		var t TupleModel
+		resp.Diagnostics.Append(obj.As(ctx, &t, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if t.Object.IsUnknown() {
+			continue
+		}
+		if hasScopeObject && !t.Object.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tuples"),
+				"Invalid Tuple Object",
+				fmt.Sprintf("Tuple object %q must not be set when the resource-level object is set. "+
+					"All tuples are scoped to object %q.", t.Object.ValueString(), config.Object.ValueString()),
+			)
+		}
+		if !hasScopeObject && t.Object.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tuples"),
+				"Missing Tuple Object",
+				fmt.Sprintf("Tuple with relation %q has no object. Set object on each tuple or set the resource-level object.",
+					t.Relation.ValueString()),
+			)
+		}
+	}
+}
+
+// ModifyPlan warns on the first apply after import without exclusive: the
+// tuples in scope that are not configured drop out of state, but they are
+// left in place rather than deleted.
+func (r *RelationshipsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RelationshipsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Exclusive.IsNull() && !plan.Exclusive.IsUnknown() && !plan.Exclusive.ValueBool() &&
+		!plan.Tuples.IsUnknown() && !plan.Tuples.Equal(state.Tuples) {
+		resp.Diagnostics.AddAttributeWarning(path.Root("tuples"), "Imported Tuples Not Adopted",
+			"Only the configured tuples are adopted from the imported scope. The other tuples in scope are "+
+				"no longer tracked by this resource and are left in place. Set exclusive = true to delete them instead.")
+	}
+}
+
+func (r *RelationshipsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RelationshipsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := r.client.Config()
+	if !helpers.ResolveProjectCreds(cfg.ProjectSlug, cfg.ProjectAPIKey, &resp.Diagnostics) {
+		return
+	}
+
+	actual, err := r.listScope(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Relationships",
+			"Could not list relationships in scope: "+err.Error(),
+		)
+		return
+	}
+
+	desired, diags := r.tuplesToRelationships(ctx, &plan, plan.Tuples)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(scopeID(plan.Namespace.ValueString(), plan.Object.ValueString()))
+
+	applied, err := r.reconcile(ctx, desired, actual, nil, plan.Exclusive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Relationships",
+			"Could not apply relationship changes: "+err.Error(),
+		)
+		// Track the tuples written by the batches that succeeded
+		plan.Tuples, diags = r.appliedTuples(ctx, &plan, actual, applied, desired)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *RelationshipsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RelationshipsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actual, err := r.listScope(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Relationships",
+			"Could not list relationships in scope: "+err.Error(),
+		)
+		return
+	}
+
+	// In exclusive mode (or after import, when nothing is tracked yet) every
+	// tuple in scope belongs to this resource, so extra tuples show up as drift.
+	// Otherwise only the tuples we manage are tracked, and missing ones drop out
+	// of state so the next plan re-creates them.
+	var managed []ory.Relationship
+	if !state.Exclusive.ValueBool() && !state.Tuples.IsNull() {
+		var diags diag.Diagnostics
+		managed, diags = r.tuplesToRelationships(ctx, &state, state.Tuples)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var diags diag.Diagnostics
+	state.Tuples, diags = r.trackedTuples(ctx, &state, actual, managed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *RelationshipsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RelationshipsResourceModel
+	var state RelationshipsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actual, err := r.listScope(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Relationships",
+			"Could not list relationships in scope: "+err.Error(),
+		)
+		return
+	}
+
+	desired, diags := r.tuplesToRelationships(ctx, &plan, plan.Tuples)
+	resp.Diagnostics.Append(diags...)
+	previous, diags := r.tuplesToRelationships(ctx, &state, state.Tuples)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	// After import exclusive is null and every tuple in scope is tracked.
+	// Without exclusive only the configured tuples are adopted, so the others
+	// must not be deleted as previously managed tuples.
+	if state.Exclusive.IsNull() && !plan.Exclusive.ValueBool() {
+		previous = nil
+	}
+
+	applied, err := r.reconcile(ctx, desired, actual, previous, plan.Exclusive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Relationships",
+			"Could not apply relationship changes: "+err.Error(),
+		)
+		// Keep tracking previous tuples that were not deleted yet, so the
+		// next apply retries, and the new tuples that were written.
+		plan.Tuples, diags = r.appliedTuples(ctx, &plan, actual, applied, append(desired, previous...))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *RelationshipsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RelationshipsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actual, err := r.listScope(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Relationships",
+			"Could not list relationships in scope: "+err.Error(),
+		)
+		return
+	}
+
+	// Only delete tuples that still exist; deleting a missing tuple is a no-op
+	// but keeps the batches small.
+	previous, diags := r.tuplesToRelationships(ctx, &state, state.Tuples)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, err := r.reconcile(ctx, nil, actual, previous, false); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Relationships",
+			"Could not delete relationships: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports all tuples in a scope. exclusive is left null so the
+// next apply can tell that the tracked tuples came from an import.
+// Import ID format: namespace or namespace:object
+func (r *RelationshipsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespace, object, hasObject := strings.Cut(req.ID, ":")
+	if namespace == "" || (hasObject && object == "") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID must be in format: namespace or namespace:object. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+	if hasObject {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object"), object)...)
+	}
+}
+
+// listScope returns all tuples in the resource's namespace/object scope.
+func (r *RelationshipsResource) listScope(ctx context.Context, m *RelationshipsResourceModel) ([]ory.Relationship, error) {
+	query := ory.RelationQuery{
+		Namespace: ory.PtrString(m.Namespace.ValueString()),
+	}
+	if !m.Object.IsNull() && !m.Object.IsUnknown() {
+		query.Object = ory.PtrString(m.Object.ValueString())
+	}
+	return r.client.ListRelationships(ctx, query)
+}
+
+// reconcile inserts desired tuples that are missing and deletes tuples that
+// exist but are no longer wanted. A tuple is unwanted if it was previously
+// managed and is not desired, or, in exclusive mode, if it is not desired.
+//
+// Changes are sent in batches of patchBatchSize. Each batch is atomic, but
+// the reconciliation as a whole is not: if a batch fails, the earlier
+// batches stay applied. The patches of those batches are returned with the
+// error.
+func (r *RelationshipsResource) reconcile(ctx context.Context, desired, actual, previous []ory.Relationship, exclusive bool) ([]ory.RelationshipPatch, error) {
+	desiredKeys := make(map[string]bool, len(desired))
+	for _, rel := range desired {
+		desiredKeys[relationshipKey(rel)] = true
+	}
+	actualKeys := make(map[string]bool, len(actual))
+	for _, rel := range actual {
+		actualKeys[relationshipKey(rel)] = true
+	}
+
+	var patches []ory.RelationshipPatch
+	queued := make(map[string]bool)
+	add := func(action string, rel ory.Relationship) {
+		key := action + " " + relationshipKey(rel)
+		if queued[key] {
+			return
+		}
+		queued[key] = true
+		patches = append(patches, ory.RelationshipPatch{
+			Action:        ory.PtrString(action),
+			RelationTuple: &rel,
+		})
+	}
+
+	for _, rel := range previous {
+		key := relationshipKey(rel)
+		if !desiredKeys[key] && actualKeys[key] {
+			add("delete", rel)
+		}
+	}
+	if exclusive {
+		for _, rel := range actual {
+			if !desiredKeys[relationshipKey(rel)] {
+				add("delete", rel)
+			}
+		}
+	}
+	for _, rel := range desired {
+		if !actualKeys[relationshipKey(rel)] {
+			add("insert", rel)
+		}
+	}
+
+	for start := 0; start < len(patches); start += patchBatchSize {
+		end := start + patchBatchSize
+		if end > len(patches) {
+			end = len(patches)
+		}
+		if err := r.client.PatchRelationships(ctx, patches[start:end]); err != nil {
+			return patches[:start], fmt.Errorf("batch %d-%d of %d: %w", start+1, end, len(patches), err)
+		}
+	}
+	return patches, nil
+}
+
+// appliedTuples returns the tuples to track after a partially applied
+// reconcile: the managed tuples that exist once the applied patches are
+// taken into account, or every tuple in scope in exclusive mode.
+func (r *RelationshipsResource) appliedTuples(ctx context.Context, m *RelationshipsResourceModel, actual []ory.Relationship, applied []ory.RelationshipPatch, managed []ory.Relationship) (types.Set, diag.Diagnostics) {
+	existing := make(map[string]ory.Relationship, len(actual))
+	for _, rel := range actual {
+		existing[relationshipKey(rel)] = rel
+	}
+	for _, patch := range applied {
+		key := relationshipKey(*patch.RelationTuple)
+		if patch.GetAction() == "delete" {
+			delete(existing, key)
+		} else {
+			existing[key] = *patch.RelationTuple
+		}
+	}
+
+	current := make([]ory.Relationship, 0, len(existing))
+	for _, rel := range existing {
+		current = append(current, rel)
+	}
+	if m.Exclusive.ValueBool() {
+		managed = nil
+	}
+	return r.trackedTuples(ctx, m, current, managed)
+}
+
+// trackedTuples returns the tuples to store in state. Without managed
+// tuples (exclusive mode, or after import) every tuple in scope is
+// tracked; otherwise only the managed tuples that exist.
+func (r *RelationshipsResource) trackedTuples(ctx context.Context, m *RelationshipsResourceModel, actual, managed []ory.Relationship) (types.Set, diag.Diagnostics) {
+	tuples := []TupleModel{}
+	if managed == nil {
+		for _, rel := range actual {
+			tuples = append(tuples, r.relationshipToTuple(m, rel))
+		}
+	} else {
+		existing := make(map[string]bool, len(actual))
+		for _, rel := range actual {
+			existing[relationshipKey(rel)] = true
+		}
+		for _, rel := range managed {
+			key := relationshipKey(rel)
+			if existing[key] {
+				tuples = append(tuples, r.relationshipToTuple(m, rel))
+				// managed may list a tuple twice (desired and previous)
+				existing[key] = false
+			}
+		}
+	}
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: tupleAttrTypes}, tuples)
+}
+
+// tuplesToRelationships converts the tuples set into API relationships,
+// filling in the scope namespace and object.
+func (r *RelationshipsResource) tuplesToRelationships(ctx context.Context, m *RelationshipsResourceModel, set types.Set) ([]ory.Relationship, diag.Diagnostics) {
+	var tuples []TupleModel
+	if !set.IsNull() && !set.IsUnknown() {
+		if diags := set.ElementsAs(ctx, &tuples, false); diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	rels := make([]ory.Relationship, 0, len(tuples))
+	for _, t := range tuples {
+		object := t.Object.ValueString()
+		if !m.Object.IsNull() {
+			object = m.Object.ValueString()
+		}
+		rel := ory.Relationship{
+			Namespace: m.Namespace.ValueString(),
+			Object:    object,
+			Relation:  t.Relation.ValueString(),
+		}
+		if !t.SubjectID.IsNull() {
+			rel.SubjectId = ory.PtrString(t.SubjectID.ValueString())
+		} else {
+			rel.SubjectSet = &ory.SubjectSet{
+				Namespace: t.SubjectSetNamespace.ValueString(),
+				Object:    t.SubjectSetObject.ValueString(),
+				Relation:  t.SubjectSetRelation.ValueString(),
+			}
+		}
+		rels = append(rels, rel)
+	}
+	sort.Slice(rels, func(i, j int) bool {
+		return relationshipKey(rels[i]) < relationshipKey(rels[j])
+	})
+	return rels, nil
+}
+
+// relationshipToTuple converts an API relationship into a tuple model. The
+// object is left null when the resource is scoped to a single object.
+func (r *RelationshipsResource) relationshipToTuple(m *RelationshipsResourceModel, rel ory.Relationship) TupleModel {
+	t := TupleModel{
+		Object:              types.StringValue(rel.Object),
+		Relation:            types.StringValue(rel.Relation),
+		SubjectID:           types.StringPointerValue(rel.SubjectId),
+		SubjectSetNamespace: types.StringNull(),
+		SubjectSetObject:    types.StringNull(),
+		SubjectSetRelation:  types.StringNull(),
+	}
+	if !m.Object.IsNull() {
+		t.Object = types.StringNull()
+	}
+	if rel.SubjectSet != nil {
+		t.SubjectSetNamespace = types.StringValue(rel.SubjectSet.Namespace)
+		t.SubjectSetObject = types.StringValue(rel.SubjectSet.Object)
+		t.SubjectSetRelation = types.StringValue(rel.SubjectSet.Relation)
+	}
+	return t
+}
+
+// relationshipKey returns a comparable key for a relationship tuple. The
+// fields are JSON encoded, so IDs containing characters such as ':', '#' or
+// '@' cannot make two different tuples share a key.
+func relationshipKey(rel ory.Relationship) string {
+	var subjectSet []string
+	if rel.SubjectSet != nil {
+		subjectSet = []string{rel.SubjectSet.Namespace, rel.SubjectSet.Object, rel.SubjectSet.Relation}
+	}
+	key, _ := json.Marshal([]interface{}{rel.Namespace, rel.Object, rel.Relation, rel.SubjectId, subjectSet})
+	return string(key)
+}
+
+// scopeID returns the resource ID for a namespace/object scope.
+func scopeID(namespace, object string) string {
+	if object == "" {
+		return namespace
+	}
+	return namespace + ":" + object
+}
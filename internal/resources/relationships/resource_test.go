@@ -0,0 +1,116 @@
+//go:build acceptance
+
+package relationships_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func TestAccRelationshipsResource_basic(t *testing.T) {
+	vars := map[string]string{"Group": "relationships-bulk"}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireKetoTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", vars),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_relationships.test", "id", "groups:relationships-bulk"),
+					resource.TestCheckResourceAttr("ory_relationships.test", "tuples.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("ory_relationships.test", "tuples.*", map[string]string{
+						"relation":   "members",
+						"subject_id": "user-bob",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("ory_relationships.test", "tuples.*", map[string]string{
+						"subject_set_namespace": "groups",
+						"subject_set_object":    "relationships-bulk-admins",
+						"subject_set_relation":  "members",
+					}),
+				),
+			},
+			// Import the whole scope: namespace:object
+			{
+				ResourceName:            "ory_relationships.test",
+				ImportState:             true,
+				ImportStateId:           "groups:relationships-bulk",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclusive"},
+			},
+			// Diff-based update: removes bob and the subject set, inserts carol
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/updated.tf.tmpl", vars),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_relationships.test", "tuples.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("ory_relationships.test", "tuples.*", map[string]string{
+						"subject_id": "user-carol",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRelationshipsResource_importNonExclusive(t *testing.T) {
+	vars := map[string]string{"Group": fmt.Sprintf("relationships-import-%d", time.Now().UnixNano())}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireKetoTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/import_seed.tf.tmpl", vars),
+				Check:  resource.TestCheckResourceAttr("ory_relationships.seed", "tuples.#", "2"),
+			},
+			// Importing the scope and applying a config without bob adopts
+			// alice only and leaves bob in place
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/import_adopt.tf.tmpl", vars),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_relationships.test", "tuples.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("ory_relationships.test", "tuples.*", map[string]string{
+						"subject_id": "user-alice",
+					}),
+					resource.TestCheckResourceAttr("data.ory_relationships.bob", "relationships.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRelationshipsResource_unknownTuples(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireKetoTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/unknown.tf.tmpl", map[string]string{"Group": "relationships-unknown"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_relationships.test", "tuples.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("ory_relationships.test", "tuples.*", map[string]string{
+						"subject_id": "user-alice",
+					}),
+				),
+			},
+		},
+	})
+}
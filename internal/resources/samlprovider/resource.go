@@ -0,0 +1,482 @@
+package samlprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ory "github.com/ory/client-go"
+
+	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
+)
+
+const (
+	samlMethodPath    = "/services/identity/config/selfservice/methods/saml"
+	samlProvidersPath = samlMethodPath + "/config/providers"
+)
+
+var (
+	_ resource.Resource                   = &SAMLProviderResource{}
+	_ resource.ResourceWithConfigure      = &SAMLProviderResource{}
+	_ resource.ResourceWithImportState    = &SAMLProviderResource{}
+	_ resource.ResourceWithValidateConfig = &SAMLProviderResource{}
+)
+
+func NewResource() resource.Resource {
+	return &SAMLProviderResource{}
+}
+
+type SAMLProviderResource struct {
+	client *client.OryClient
+}
+
+type SAMLProviderResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	ProviderID     types.String `tfsdk:"provider_id"`
+	Label          types.String `tfsdk:"label"`
+	IDPMetadataURL types.String `tfsdk:"idp_metadata_url"`
+	IDPMetadataXML types.String `tfsdk:"idp_metadata_xml"`
+	EntityID       types.String `tfsdk:"entity_id"`
+	MapperURL      types.String `tfsdk:"mapper_url"`
+	MapperJsonnet  types.String `tfsdk:"mapper_jsonnet"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func (r *SAMLProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saml_provider"
+}
+
+func (r *SAMLProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Ory Network SAML 2.0 identity provider connection.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (same as provider_id).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Project ID. If not set, uses provider's project_id.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"provider_id": schema.StringAttribute{
+				Description: "Unique identifier for the SAML connection (used in the ACS and metadata URLs).",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label shown on the sign-in button.",
+				Optional:    true,
+			},
+			"idp_metadata_url": schema.StringAttribute{
+				Description: "HTTPS URL of the identity provider's SAML metadata. Exactly one of idp_metadata_url and idp_metadata_xml is required.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("idp_metadata_xml")),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https://`), "must be an https:// URL"),
+				},
+			},
+			"idp_metadata_xml": schema.StringAttribute{
+				Description: "The identity provider's SAML metadata XML. It is parsed and validated at plan time.",
+				Optional:    true,
+			},
+			"entity_id": schema.StringAttribute{
+				Description: "Entity ID of the identity provider. With idp_metadata_xml, it must match the entityID of the metadata.",
+				Optional:    true,
+			},
+			"mapper_url": schema.StringAttribute{
+				Description: "Jsonnet mapper URL that maps SAML attributes to identity traits. Can be a URL or base64-encoded Jsonnet (base64://...). Exactly one of mapper_url and mapper_jsonnet is required.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("mapper_jsonnet")),
+					helpers.JsonnetValidator(),
+				},
+			},
+			"mapper_jsonnet": schema.StringAttribute{
+				Description: "Inline Jsonnet mapper that maps SAML attributes (std.extVar('claims')) to identity traits. The provider sends it as a base64:// mapper URL and checks it for syntax errors at plan time.",
+				Optional:    true,
+				Validators: []validator.String{
+					helpers.JsonnetValidator(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "ID of the ory_organization this connection is the enterprise SSO connection for.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *SAMLProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	oryClient, ok := req.ProviderData.(*client.OryClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.OryClient, got: %T", req.ProviderData))
+		return
+	}
+	r.client = oryClient
+}
+
+func (r *SAMLProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SAMLProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.IDPMetadataXML.IsNull() || config.IDPMetadataXML.IsUnknown() {
+		return
+	}
+
+	metadata, err := helpers.ParseSAMLMetadata(config.IDPMetadataXML.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("idp_metadata_xml"), "Invalid SAML Metadata", err.Error())
+		return
+	}
+
+	if !config.EntityID.IsNull() && !config.EntityID.IsUnknown() && config.EntityID.ValueString() != metadata.EntityID {
+		resp.Diagnostics.AddAttributeError(path.Root("entity_id"), "SAML Entity ID Mismatch",
+			fmt.Sprintf("entity_id is %q, but the metadata describes %q.", config.EntityID.ValueString(), metadata.EntityID))
+	}
+}
+
+// buildProviderConfig converts the plan to a provider entry of the SAML
+// method. Inline metadata and mappers are sent as base64:// URLs.
+func buildProviderConfig(plan *SAMLProviderResourceModel) map[string]interface{} {
+	config := map[string]interface{}{
+		"id":       plan.ProviderID.ValueString(),
+		"provider": "generic",
+	}
+
+	if !plan.IDPMetadataXML.IsNull() && !plan.IDPMetadataXML.IsUnknown() {
+		config["raw_idp_metadata_xml"] = encodeBase64URL(plan.IDPMetadataXML.ValueString())
+	}
+	if !plan.MapperJsonnet.IsNull() && !plan.MapperJsonnet.IsUnknown() {
+		config["mapper_url"] = encodeBase64URL(plan.MapperJsonnet.ValueString())
+	}
+
+	for key, value := range map[string]types.String{
+		"label":            plan.Label,
+		"idp_metadata_url": plan.IDPMetadataURL,
+		"idp_entity_id":    plan.EntityID,
+		"mapper_url":       plan.MapperURL,
+		"organization_id":  plan.OrganizationID,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			config[key] = value.ValueString()
+		}
+	}
+
+	return config
+}
+
+func encodeBase64URL(content string) string {
+	return "base64://" + base64.StdEncoding.EncodeToString([]byte(content))
+}
+
+// decodeBase64URL returns the content of a base64:// URL. ok is false for
+// other values, e.g. URLs of content the API stored.
+func decodeBase64URL(value string) (content string, ok bool) {
+	if !strings.HasPrefix(value, "base64://") {
+		return "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, "base64://"))
+	if err != nil {
+		return "", false
+	}
+	return string(decoded), true
+}
+
+func providersFromProject(project *ory.Project) []map[string]interface{} {
+	if project.Services.Identity == nil {
+		return []map[string]interface{}{}
+	}
+
+	selfservice, _ := project.Services.Identity.Config["selfservice"].(map[string]interface{})
+	methods, _ := selfservice["methods"].(map[string]interface{})
+	saml, _ := methods["saml"].(map[string]interface{})
+	config, _ := saml["config"].(map[string]interface{})
+	providers, _ := config["providers"].([]interface{})
+
+	result := make([]map[string]interface{}, 0, len(providers))
+	for _, p := range providers {
+		if pm, ok := p.(map[string]interface{}); ok {
+			result = append(result, pm)
+		}
+	}
+	return result
+}
+
+func findProviderIndex(providers []map[string]interface{}, providerID string) int {
+	for i, p := range providers {
+		if p["id"] == providerID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *SAMLProviderResource) getProviders(ctx context.Context, projectID string) ([]map[string]interface{}, error) {
+	project, err := r.client.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+	}
+	return providersFromProject(project), nil
+}
+
+func (r *SAMLProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SAMLProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providers, err := r.getProviders(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Getting SAML Providers", err.Error())
+		return
+	}
+	if findProviderIndex(providers, plan.ProviderID.ValueString()) >= 0 {
+		resp.Diagnostics.AddError("SAML Provider Already Exists",
+			fmt.Sprintf("A SAML provider with ID %q is already configured. Import it instead.", plan.ProviderID.ValueString()))
+		return
+	}
+
+	providerConfig := buildProviderConfig(&plan)
+	var patch ory.JsonPatch
+	if len(providers) == 0 {
+		// The first provider initializes the SAML method
+		patch = ory.JsonPatch{
+			Op:   "add",
+			Path: samlMethodPath,
+			Value: map[string]interface{}{
+				"enabled": true,
+				"config": map[string]interface{}{
+					"providers": []interface{}{providerConfig},
+				},
+			},
+		}
+	} else {
+		patch = ory.JsonPatch{
+			Op:    "add",
+			Path:  samlProvidersPath + "/-",
+			Value: providerConfig,
+		}
+	}
+
+	if _, err := r.client.PatchProject(ctx, projectID, []ory.JsonPatch{patch}); err != nil {
+		resp.Diagnostics.AddError("Error Creating SAML Provider", err.Error())
+		return
+	}
+
+	plan.ID = plan.ProviderID
+	plan.ProjectID = types.StringValue(projectID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *SAMLProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SAMLProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(state.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	providerID := state.ProviderID.ValueString()
+
+	var providers []map[string]interface{}
+	if cached := r.client.GetCachedProject(projectID); cached != nil {
+		providers = providersFromProject(cached)
+	}
+	if findProviderIndex(providers, providerID) < 0 {
+		var err error
+		providers, err = r.getProviders(ctx, projectID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading SAML Provider", err.Error())
+			return
+		}
+	}
+
+	index := findProviderIndex(providers, providerID)
+	if index < 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	provider := providers[index]
+
+	state.Label = optionalString(provider, "label")
+	state.IDPMetadataURL = optionalString(provider, "idp_metadata_url")
+	state.EntityID = optionalString(provider, "idp_entity_id")
+	state.OrganizationID = optionalString(provider, "organization_id")
+
+	// Inline metadata and mappers are stored as base64:// URLs. The API may
+	// store them and return a URL instead; then the configured value is kept.
+	state.IDPMetadataXML = readInline(provider, "raw_idp_metadata_xml", state.IDPMetadataXML)
+	mapper, _ := provider["mapper_url"].(string)
+	switch {
+	case !state.MapperURL.IsNull():
+		if mapper != "" {
+			state.MapperURL = types.StringValue(mapper)
+		}
+	case !state.MapperJsonnet.IsNull():
+		state.MapperJsonnet = readInline(provider, "mapper_url", state.MapperJsonnet)
+	default:
+		// After import, inline mappers become mapper_jsonnet.
+		if content, ok := decodeBase64URL(mapper); ok {
+			state.MapperJsonnet = types.StringValue(content)
+		} else if mapper != "" {
+			state.MapperURL = types.StringValue(mapper)
+		}
+	}
+
+	state.ID = types.StringValue(providerID)
+	state.ProjectID = types.StringValue(projectID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// readInline returns the decoded content of a base64:// value, the prior
+// value if the API returned a reference to stored content, or null if the
+// value is not set.
+func readInline(provider map[string]interface{}, key string, prior types.String) types.String {
+	value, _ := provider[key].(string)
+	if value == "" {
+		return types.StringNull()
+	}
+	if content, ok := decodeBase64URL(value); ok {
+		return types.StringValue(content)
+	}
+	return prior
+}
+
+// optionalString returns the string value of key, or null if it is not set.
+func optionalString(provider map[string]interface{}, key string) types.String {
+	if v, ok := provider[key].(string); ok && v != "" {
+		return types.StringValue(v)
+	}
+	return types.StringNull()
+}
+
+func (r *SAMLProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SAMLProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(plan.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providers, err := r.getProviders(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Getting SAML Providers", err.Error())
+		return
+	}
+
+	index := findProviderIndex(providers, plan.ProviderID.ValueString())
+	if index < 0 {
+		resp.Diagnostics.AddError("SAML Provider Not Found",
+			fmt.Sprintf("SAML provider %q not found", plan.ProviderID.ValueString()))
+		return
+	}
+
+	patches := []ory.JsonPatch{{
+		Op:    "replace",
+		Path:  fmt.Sprintf("%s/%d", samlProvidersPath, index),
+		Value: buildProviderConfig(&plan),
+	}}
+	if _, err := r.client.PatchProject(ctx, projectID, patches); err != nil {
+		resp.Diagnostics.AddError("Error Updating SAML Provider", err.Error())
+		return
+	}
+
+	plan.ID = plan.ProviderID
+	plan.ProjectID = types.StringValue(projectID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *SAMLProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SAMLProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := helpers.ResolveProjectID(state.ProjectID, r.client.ProjectID(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providers, err := r.getProviders(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Getting SAML Providers", err.Error())
+		return
+	}
+
+	index := findProviderIndex(providers, state.ProviderID.ValueString())
+	if index < 0 {
+		return // Already deleted
+	}
+
+	var patch ory.JsonPatch
+	if len(providers) == 1 {
+		// Disable the SAML method rather than leaving it enabled without providers
+		patch = ory.JsonPatch{
+			Op:   "replace",
+			Path: samlMethodPath,
+			Value: map[string]interface{}{
+				"enabled": false,
+				"config": map[string]interface{}{
+					"providers": []interface{}{},
+				},
+			},
+		}
+	} else {
+		patch = ory.JsonPatch{
+			Op:   "remove",
+			Path: fmt.Sprintf("%s/%d", samlProvidersPath, index),
+		}
+	}
+
+	if _, err := r.client.PatchProject(ctx, projectID, []ory.JsonPatch{patch}); err != nil {
+		resp.Diagnostics.AddError("Error Deleting SAML Provider", err.Error())
+		return
+	}
+}
+
+func (r *SAMLProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("provider_id"), req.ID)...)
+}
@@ -0,0 +1,88 @@
+//go:build acceptance
+
+package samlprovider_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/ory/terraform-provider-ory/internal/acctest"
+)
+
+func loadMetadata(t *testing.T) string {
+	t.Helper()
+	metadata, err := os.ReadFile("testdata/idp-metadata.xml")
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	return string(metadata)
+}
+
+func TestAccSAMLProviderResource_basic(t *testing.T) {
+	metadata := loadMetadata(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireB2BTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"Label": "Test SAML", "Metadata": metadata}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_saml_provider.test", "id", "test-saml"),
+					resource.TestCheckResourceAttr("ory_saml_provider.test", "label", "Test SAML"),
+					resource.TestCheckResourceAttr("ory_saml_provider.test", "entity_id", "https://idp.example.com/metadata"),
+					resource.TestCheckResourceAttrSet("ory_saml_provider.test", "idp_metadata_xml"),
+					resource.TestCheckResourceAttrSet("ory_saml_provider.test", "mapper_jsonnet"),
+				),
+			},
+			// ImportState using provider_id
+			{
+				ResourceName:      "ory_saml_provider.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API may return URLs of the stored metadata and mapper
+				ImportStateVerifyIgnore: []string{"idp_metadata_xml", "mapper_jsonnet", "mapper_url"},
+			},
+			// Update
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/basic.tf.tmpl", map[string]string{"Label": "Test SAML Updated", "Metadata": metadata}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_saml_provider.test", "label", "Test SAML Updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSAMLProviderResource_entityIDMismatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/entity_id_mismatch.tf.tmpl", map[string]string{"Metadata": loadMetadata(t)}),
+				ExpectError: regexp.MustCompile(`SAML Entity ID Mismatch`),
+			},
+		},
+	})
+}
+
+func TestAccSAMLProviderResource_invalidMetadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_metadata.tf.tmpl", nil),
+				ExpectError: regexp.MustCompile(`no IDPSSODescriptor`),
+			},
+		},
+	})
+}
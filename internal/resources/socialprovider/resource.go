@@ -2,14 +2,19 @@ package socialprovider
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	ory "github.com/ory/client-go"
 
@@ -18,9 +23,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &SocialProviderResource{}
-	_ resource.ResourceWithConfigure   = &SocialProviderResource{}
-	_ resource.ResourceWithImportState = &SocialProviderResource{}
+	_ resource.Resource                   = &SocialProviderResource{}
+	_ resource.ResourceWithConfigure      = &SocialProviderResource{}
+	_ resource.ResourceWithImportState    = &SocialProviderResource{}
+	_ resource.ResourceWithValidateConfig = &SocialProviderResource{}
 )
 
 func NewResource() resource.Resource {
@@ -32,18 +38,30 @@ type SocialProviderResource struct {
 }
 
 type SocialProviderResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	ProjectID    types.String `tfsdk:"project_id"`
-	ProviderID   types.String `tfsdk:"provider_id"`
-	ProviderType types.String `tfsdk:"provider_type"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	IssuerURL    types.String `tfsdk:"issuer_url"`
-	Scope        types.List   `tfsdk:"scope"`
-	MapperURL    types.String `tfsdk:"mapper_url"`
-	AuthURL      types.String `tfsdk:"auth_url"`
-	TokenURL     types.String `tfsdk:"token_url"`
-	Tenant       types.String `tfsdk:"tenant"`
+	ID            types.String `tfsdk:"id"`
+	ProjectID     types.String `tfsdk:"project_id"`
+	ProviderID    types.String `tfsdk:"provider_id"`
+	ProviderType  types.String `tfsdk:"provider_type"`
+	ClientID      types.String `tfsdk:"client_id"`
+	ClientSecret  types.String `tfsdk:"client_secret"`
+	IssuerURL     types.String `tfsdk:"issuer_url"`
+	Scope         types.List   `tfsdk:"scope"`
+	MapperURL     types.String `tfsdk:"mapper_url"`
+	MapperJsonnet types.String `tfsdk:"mapper_jsonnet"`
+	AuthURL       types.String `tfsdk:"auth_url"`
+	TokenURL      types.String `tfsdk:"token_url"`
+	Tenant        types.String `tfsdk:"tenant"`
+
+	AppleTeamID                types.String           `tfsdk:"apple_team_id"`
+	ApplePrivateKeyID          types.String           `tfsdk:"apple_private_key_id"`
+	ApplePrivateKey            types.String           `tfsdk:"apple_private_key"`
+	RequestedClaims            helpers.NormalizedJSON `tfsdk:"requested_claims"`
+	AdditionalIDTokenAudiences types.List             `tfsdk:"additional_id_token_audiences"`
+	PKCE                       types.String           `tfsdk:"pkce"`
+	ClaimsSource               types.String           `tfsdk:"claims_source"`
+	SubjectSource              types.String           `tfsdk:"subject_source"`
+	Label                      types.String           `tfsdk:"label"`
+	OrganizationID             types.String           `tfsdk:"organization_id"`
 }
 
 func (r *SocialProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -89,8 +107,8 @@ func (r *SocialProviderResource) Schema(ctx context.Context, req resource.Schema
 				Required:    true,
 			},
 			"client_secret": schema.StringAttribute{
-				Description: "OAuth2 client secret from the provider.",
-				Required:    true,
+				Description: "OAuth2 client secret from the provider. Required unless provider_type is 'apple' and apple_private_key is set.",
+				Optional:    true,
 				Sensitive:   true,
 			},
 			"issuer_url": schema.StringAttribute{
@@ -103,8 +121,19 @@ func (r *SocialProviderResource) Schema(ctx context.Context, req resource.Schema
 				ElementType: types.StringType,
 			},
 			"mapper_url": schema.StringAttribute{
-				Description: "Jsonnet mapper URL for claims mapping. Can be a URL or base64-encoded Jsonnet (base64://...). If not set, a default mapper that extracts email from claims will be used.",
+				Description: "Jsonnet mapper URL for claims mapping. Can be a URL or base64-encoded Jsonnet (base64://...). If neither mapper_url nor mapper_jsonnet is set, a default mapper that extracts email from claims will be used. base64:// mappers are checked for syntax errors at plan time.",
+				Optional:    true,
+				Validators: []validator.String{
+					helpers.JsonnetValidator(),
+				},
+			},
+			"mapper_jsonnet": schema.StringAttribute{
+				Description: "Inline Jsonnet mapper for claims mapping. The provider sends it as a base64:// mapper URL and checks it for syntax errors at plan time. Conflicts with mapper_url.",
 				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("mapper_url")),
+					helpers.JsonnetValidator(),
+				},
 			},
 			"auth_url": schema.StringAttribute{
 				Description: "Custom authorization URL (for non-standard providers).",
@@ -118,6 +147,58 @@ func (r *SocialProviderResource) Schema(ctx context.Context, req resource.Schema
 				Description: "Tenant ID (for Microsoft/Azure providers).",
 				Optional:    true,
 			},
+			"apple_team_id": schema.StringAttribute{
+				Description: "Apple Developer team ID. Only for provider_type 'apple'.",
+				Optional:    true,
+			},
+			"apple_private_key_id": schema.StringAttribute{
+				Description: "ID of the Sign in with Apple private key. Only for provider_type 'apple'.",
+				Optional:    true,
+			},
+			"apple_private_key": schema.StringAttribute{
+				Description: "Sign in with Apple private key (PEM). Ory uses it to generate the client secret, so client_secret can be omitted. Requires apple_team_id and apple_private_key_id. Only for provider_type 'apple'.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"requested_claims": schema.StringAttribute{
+				Description: "OpenID Connect claims request as JSON string, e.g. to request the email claim with essential: true.",
+				CustomType:  helpers.NormalizedJSONType{},
+				Optional:    true,
+			},
+			"additional_id_token_audiences": schema.ListAttribute{
+				Description: "Additional audiences accepted in ID tokens, e.g. the client IDs of native iOS or Android apps that sign in with an ID token.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"pkce": schema.StringAttribute{
+				Description: "PKCE mode: 'auto' (use PKCE if the provider supports it), 'force' or 'never'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "force", "never"),
+				},
+			},
+			"claims_source": schema.StringAttribute{
+				Description: "Where claims are read from: 'id_token' (default) or 'userinfo'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("id_token", "userinfo"),
+				},
+			},
+			"subject_source": schema.StringAttribute{
+				Description: "Where the subject identifier is read from: 'id_token' (default), 'userinfo', or 'me' (Microsoft Graph, only for provider_type 'microsoft').",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("id_token", "userinfo", "me"),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Label shown on the sign-in button.",
+				Optional:    true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "ID of the ory_organization this provider is an enterprise SSO connection for. Organization-bound providers are only offered to users whose email domain belongs to the organization.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -135,17 +216,72 @@ func (r *SocialProviderResource) Configure(ctx context.Context, req resource.Con
 	r.client = oryClient
 }
 
+// appleOnlyAttributes are the attributes that only apply to Sign in with Apple.
+var appleOnlyAttributes = []string{"apple_team_id", "apple_private_key_id", "apple_private_key"}
+
+func (r *SocialProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SocialProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ProviderType.IsUnknown() {
+		return
+	}
+	providerType := config.ProviderType.ValueString()
+
+	apple := map[string]types.String{
+		"apple_team_id":        config.AppleTeamID,
+		"apple_private_key_id": config.ApplePrivateKeyID,
+		"apple_private_key":    config.ApplePrivateKey,
+	}
+	if providerType != "apple" {
+		for _, name := range appleOnlyAttributes {
+			if !apple[name].IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid Attribute Combination",
+					fmt.Sprintf("%s can only be used when provider_type is \"apple\".", name))
+			}
+		}
+	} else if !config.ApplePrivateKey.IsNull() {
+		for _, name := range appleOnlyAttributes {
+			if apple[name].IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root(name), "Missing Attribute Configuration",
+					fmt.Sprintf("%s is required when apple_private_key is set.", name))
+			}
+		}
+	}
+
+	if config.ClientSecret.IsNull() && (providerType != "apple" || config.ApplePrivateKey.IsNull()) {
+		resp.Diagnostics.AddAttributeError(path.Root("client_secret"), "Missing Attribute Configuration",
+			"client_secret is required unless provider_type is \"apple\" and apple_private_key is set.")
+	}
+
+	if config.SubjectSource.ValueString() == "me" && providerType != "microsoft" {
+		resp.Diagnostics.AddAttributeError(path.Root("subject_source"), "Invalid Attribute Combination",
+			"subject_source \"me\" can only be used when provider_type is \"microsoft\".")
+	}
+
+	if !config.RequestedClaims.IsNull() && !config.RequestedClaims.IsUnknown() {
+		var claims map[string]interface{}
+		if err := config.RequestedClaims.Unmarshal(&claims); err != nil || claims == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("requested_claims"), "Invalid Requested Claims",
+				"requested_claims must be a JSON object, e.g. {\"id_token\": {\"email\": {\"essential\": true}}}.")
+		}
+	}
+}
+
 // defaultMapperURL returns the default Jsonnet mapper for common providers.
 // This is a simple mapper that extracts email and subject from the claims.
 // The base64-encoded Jsonnet maps claims to identity traits.
 const defaultMapperURL = "base64://bG9jYWwgY2xhaW1zID0gc3RkLmV4dFZhcignY2xhaW1zJyk7CnsKICBpZGVudGl0eTogewogICAgdHJhaXRzOiB7CiAgICAgIGVtYWlsOiBjbGFpbXMuZW1haWwsCiAgICB9LAogIH0sCn0="
 
-func (r *SocialProviderResource) buildProviderConfig(ctx context.Context, plan *SocialProviderResourceModel) map[string]interface{} {
+func (r *SocialProviderResource) buildProviderConfig(ctx context.Context, plan *SocialProviderResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	config := map[string]interface{}{
-		"id":            plan.ProviderID.ValueString(),
-		"provider":      plan.ProviderType.ValueString(),
-		"client_id":     plan.ClientID.ValueString(),
-		"client_secret": plan.ClientSecret.ValueString(),
+		"id":        plan.ProviderID.ValueString(),
+		"provider":  plan.ProviderType.ValueString(),
+		"client_id": plan.ClientID.ValueString(),
+	}
+
+	if !plan.ClientSecret.IsNull() && !plan.ClientSecret.IsUnknown() {
+		config["client_secret"] = plan.ClientSecret.ValueString()
 	}
 
 	if !plan.IssuerURL.IsNull() && !plan.IssuerURL.IsUnknown() {
@@ -153,12 +289,14 @@ func (r *SocialProviderResource) buildProviderConfig(ctx context.Context, plan *
 	}
 	if !plan.Scope.IsNull() && !plan.Scope.IsUnknown() {
 		var scope []string
-		plan.Scope.ElementsAs(ctx, &scope, false)
+		diags.Append(plan.Scope.ElementsAs(ctx, &scope, false)...)
 		config["scope"] = scope
 	}
 	// mapper_url is required by the Ory API - use default if not provided
 	if !plan.MapperURL.IsNull() && !plan.MapperURL.IsUnknown() && plan.MapperURL.ValueString() != "" {
 		config["mapper_url"] = plan.MapperURL.ValueString()
+	} else if !plan.MapperJsonnet.IsNull() && !plan.MapperJsonnet.IsUnknown() && plan.MapperJsonnet.ValueString() != "" {
+		config["mapper_url"] = "base64://" + base64.StdEncoding.EncodeToString([]byte(plan.MapperJsonnet.ValueString()))
 	} else {
 		config["mapper_url"] = defaultMapperURL
 	}
@@ -172,7 +310,35 @@ func (r *SocialProviderResource) buildProviderConfig(ctx context.Context, plan *
 		config["microsoft_tenant"] = plan.Tenant.ValueString()
 	}
 
-	return config
+	for key, value := range map[string]types.String{
+		"apple_team_id":        plan.AppleTeamID,
+		"apple_private_key_id": plan.ApplePrivateKeyID,
+		"apple_private_key":    plan.ApplePrivateKey,
+		"pkce":                 plan.PKCE,
+		"claims_source":        plan.ClaimsSource,
+		"subject_source":       plan.SubjectSource,
+		"label":                plan.Label,
+		"organization_id":      plan.OrganizationID,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			config[key] = value.ValueString()
+		}
+	}
+	if !plan.RequestedClaims.IsNull() && !plan.RequestedClaims.IsUnknown() {
+		var claims map[string]interface{}
+		if err := plan.RequestedClaims.Unmarshal(&claims); err != nil {
+			diags.AddAttributeError(path.Root("requested_claims"), "Invalid Requested Claims",
+				"requested_claims must be a JSON object: "+err.Error())
+		}
+		config["requested_claims"] = claims
+	}
+	if !plan.AdditionalIDTokenAudiences.IsNull() && !plan.AdditionalIDTokenAudiences.IsUnknown() {
+		var audiences []string
+		diags.Append(plan.AdditionalIDTokenAudiences.ElementsAs(ctx, &audiences, false)...)
+		config["additional_id_token_audiences"] = audiences
+	}
+
+	return config, diags
 }
 
 func extractProvidersFromProject(project *ory.Project) []map[string]interface{} {
@@ -248,7 +414,11 @@ func (r *SocialProviderResource) Create(ctx context.Context, req resource.Create
 		projectID = r.client.ProjectID()
 	}
 
-	providerConfig := r.buildProviderConfig(ctx, &plan)
+	providerConfig, diags := r.buildProviderConfig(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Get current providers
 	providers, err := r.getProviders(ctx, projectID)
@@ -414,6 +584,17 @@ func (r *SocialProviderResource) Read(ctx context.Context, req resource.ReadRequ
 		}
 	}
 
+	// Read mapper_jsonnet back from the base64:// mapper URL so changes made
+	// outside of Terraform show up as drift. The API may store the mapper
+	// and return a URL to it instead; then the configured value is kept.
+	if !state.MapperJsonnet.IsNull() && !state.MapperJsonnet.IsUnknown() {
+		if mapper, ok := provider["mapper_url"].(string); ok && strings.HasPrefix(mapper, "base64://") {
+			if decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(mapper, "base64://")); err == nil {
+				state.MapperJsonnet = types.StringValue(string(decoded))
+			}
+		}
+	}
+
 	// Read auth_url for custom providers
 	if authURL, ok := provider["auth_url"].(string); ok && authURL != "" {
 		state.AuthURL = types.StringValue(authURL)
@@ -429,6 +610,52 @@ func (r *SocialProviderResource) Read(ctx context.Context, req resource.ReadRequ
 		state.Tenant = types.StringValue(tenant)
 	}
 
+	// Read the remaining provider options. apple_private_key is sensitive
+	// and, like client_secret, not read back.
+	state.AppleTeamID = optionalString(provider, "apple_team_id")
+	state.ApplePrivateKeyID = optionalString(provider, "apple_private_key_id")
+	state.Label = optionalString(provider, "label")
+	state.OrganizationID = optionalString(provider, "organization_id")
+
+	// The API may fill in defaults for these, so, like mapper_url, they are
+	// only read back when configured.
+	if !state.PKCE.IsNull() {
+		state.PKCE = optionalString(provider, "pkce")
+	}
+	if !state.ClaimsSource.IsNull() {
+		state.ClaimsSource = optionalString(provider, "claims_source")
+	}
+	if !state.SubjectSource.IsNull() {
+		state.SubjectSource = optionalString(provider, "subject_source")
+	}
+
+	if claims, ok := provider["requested_claims"].(map[string]interface{}); ok && len(claims) > 0 {
+		claimsJSON, err := helpers.NewNormalizedJSONFromObject(claims)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Social Provider", err.Error())
+			return
+		}
+		state.RequestedClaims = claimsJSON
+	} else {
+		state.RequestedClaims = helpers.NewNormalizedJSONNull()
+	}
+
+	if audiences, ok := provider["additional_id_token_audiences"].([]interface{}); ok && len(audiences) > 0 {
+		audienceStrings := make([]string, 0, len(audiences))
+		for _, a := range audiences {
+			if str, ok := a.(string); ok {
+				audienceStrings = append(audienceStrings, str)
+			}
+		}
+		audienceList, diags := types.ListValueFrom(ctx, types.StringType, audienceStrings)
+		resp.Diagnostics.Append(diags...)
+		if !resp.Diagnostics.HasError() {
+			state.AdditionalIDTokenAudiences = audienceList
+		}
+	} else {
+		state.AdditionalIDTokenAudiences = types.ListNull(types.StringType)
+	}
+
 	// Always ensure ID and ProjectID are set in state
 	state.ID = types.StringValue(providerID)
 	state.ProjectID = types.StringValue(projectID)
@@ -461,7 +688,11 @@ func (r *SocialProviderResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	providerConfig := r.buildProviderConfig(ctx, &plan)
+	providerConfig, diags := r.buildProviderConfig(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	patches := []ory.JsonPatch{{
 		Op:    "replace",
 		Path:  fmt.Sprintf("/services/identity/config/selfservice/methods/oidc/config/providers/%d", index),
@@ -538,3 +769,11 @@ func (r *SocialProviderResource) ImportState(ctx context.Context, req resource.I
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("provider_id"), req.ID)...)
 }
+
+// optionalString returns the string value of key, or null if it is not set.
+func optionalString(provider map[string]interface{}, key string) types.String {
+	if v, ok := provider[key].(string); ok && v != "" {
+		return types.StringValue(v)
+	}
+	return types.StringNull()
+}
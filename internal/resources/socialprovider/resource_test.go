@@ -3,6 +3,7 @@
 package socialprovider_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -39,3 +40,110 @@ func TestAccSocialProviderResource_basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSocialProviderResource_mapperJsonnet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireSocialProviderTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Create with an inline mapper
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/mapper_jsonnet.tf.tmpl", map[string]string{"ExtraTrait": ""}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_social_provider.test", "provider_id", "test-github-mapper"),
+					resource.TestCheckResourceAttrSet("ory_social_provider.test", "mapper_jsonnet"),
+					resource.TestCheckNoResourceAttr("ory_social_provider.test", "mapper_url"),
+				),
+			},
+			// Update the mapper
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/mapper_jsonnet.tf.tmpl", map[string]string{"ExtraTrait": "username: claims.preferred_username,"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("ory_social_provider.test", "mapper_jsonnet", regexp.MustCompile(`preferred_username`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSocialProviderResource_mapperConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/mapper_conflict.tf.tmpl", nil),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccSocialProviderResource_options(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.AccPreCheck(t)
+			acctest.RequireSocialProviderTests(t)
+		},
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			// Create with all generic OIDC options
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/options.tf.tmpl", map[string]string{"Label": "Corporate SSO"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_social_provider.test", "label", "Corporate SSO"),
+					resource.TestCheckResourceAttr("ory_social_provider.test", "pkce", "force"),
+					resource.TestCheckResourceAttr("ory_social_provider.test", "claims_source", "userinfo"),
+					resource.TestCheckResourceAttr("ory_social_provider.test", "subject_source", "userinfo"),
+					resource.TestCheckResourceAttr("ory_social_provider.test", "additional_id_token_audiences.#", "1"),
+					resource.TestCheckResourceAttr("ory_social_provider.test", "additional_id_token_audiences.0", "test-ios-client-id"),
+					resource.TestCheckResourceAttrSet("ory_social_provider.test", "requested_claims"),
+				),
+			},
+			// ImportState using provider_id
+			{
+				ResourceName:            "ory_social_provider.test",
+				ImportState:             true,
+				ImportStateId:           "test-generic-options",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+			// Update
+			{
+				Config: acctest.LoadTestConfig(t, "testdata/options.tf.tmpl", map[string]string{"Label": "Sign in with SSO"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ory_social_provider.test", "label", "Sign in with SSO"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSocialProviderResource_invalidAppleOptions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_apple_options.tf.tmpl", nil),
+				ExpectError: regexp.MustCompile(`apple_team_id can only be used when provider_type is "apple"`),
+			},
+		},
+	})
+}
+
+func TestAccSocialProviderResource_invalidRequestedClaims(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.LoadTestConfig(t, "testdata/invalid_requested_claims.tf.tmpl", nil),
+				ExpectError: regexp.MustCompile(`requested_claims must be a JSON object`),
+			},
+		},
+	})
+}
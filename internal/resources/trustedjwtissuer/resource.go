@@ -2,7 +2,6 @@ package trustedjwtissuer
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -20,6 +19,7 @@ import (
 	ory "github.com/ory/client-go"
 
 	"github.com/ory/terraform-provider-ory/internal/client"
+	"github.com/ory/terraform-provider-ory/internal/helpers"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -41,14 +41,14 @@ type TrustedJwtIssuerResource struct {
 
 // TrustedJwtIssuerResourceModel describes the resource data model.
 type TrustedJwtIssuerResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Issuer          types.String `tfsdk:"issuer"`
-	Scope           types.List   `tfsdk:"scope"`
-	ExpiresAt       types.String `tfsdk:"expires_at"`
-	Subject         types.String `tfsdk:"subject"`
-	AllowAnySubject types.Bool   `tfsdk:"allow_any_subject"`
-	Jwk             types.String `tfsdk:"jwk"`
-	CreatedAt       types.String `tfsdk:"created_at"`
+	ID              types.String           `tfsdk:"id"`
+	Issuer          types.String           `tfsdk:"issuer"`
+	Scope           types.List             `tfsdk:"scope"`
+	ExpiresAt       types.String           `tfsdk:"expires_at"`
+	Subject         types.String           `tfsdk:"subject"`
+	AllowAnySubject types.Bool             `tfsdk:"allow_any_subject"`
+	Jwk             helpers.NormalizedJSON `tfsdk:"jwk"`
+	CreatedAt       types.String           `tfsdk:"created_at"`
 }
 
 func (r *TrustedJwtIssuerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -145,10 +145,11 @@ func (r *TrustedJwtIssuerResource) Schema(ctx context.Context, req resource.Sche
 			},
 			"jwk": schema.StringAttribute{
 				Description: "The JSON Web Key (JWK) as a JSON string. This is the public key used to verify JWTs from the issuer.",
+				CustomType:  helpers.NormalizedJSONType{},
 				Required:    true,
 				Sensitive:   true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					helpers.RequiresReplaceIfJSONChanged(),
 				},
 			},
 			"created_at": schema.StringAttribute{
@@ -206,7 +207,7 @@ func (r *TrustedJwtIssuerResource) Create(ctx context.Context, req resource.Crea
 
 	// Parse JWK
 	var jwk ory.JsonWebKey
-	if err := json.Unmarshal([]byte(plan.Jwk.ValueString()), &jwk); err != nil {
+	if err := plan.Jwk.Unmarshal(&jwk); err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid JWK JSON",
 			"Could not parse jwk as JSON Web Key: "+err.Error(),
@@ -309,13 +310,26 @@ func (r *TrustedJwtIssuerResource) Read(ctx context.Context, req resource.ReadRe
 func (r *TrustedJwtIssuerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// This resource does not support in-place updates.
 	// All mutable-looking fields that are not marked RequiresReplace will trigger
-	// a destroy-and-recreate via plan modifiers. If Terraform still routes here,
-	// return an explicit error.
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Trusted OAuth2 JWT grant issuers cannot be updated in place. "+
-			"The resource must be destroyed and recreated to apply changes.",
-	)
+	// a destroy-and-recreate via plan modifiers. The only exception is a jwk
+	// that was reformatted without changing the key, which is recorded as-is.
+	var plan, state TrustedJwtIssuerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !helpers.JSONSemanticallyEqual(plan.Jwk.ValueString(), state.Jwk.ValueString()) {
+		resp.Diagnostics.AddError(
+			"Update Not Supported",
+			"Trusted OAuth2 JWT grant issuers cannot be updated in place. "+
+				"The resource must be destroyed and recreated to apply changes.",
+		)
+		return
+	}
+
+	state.Jwk = plan.Jwk
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *TrustedJwtIssuerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {